@@ -0,0 +1,96 @@
+package builder
+
+import (
+    "fmt"
+)
+
+type MonthGroup struct {
+    Month int
+    Name  string
+    Link  string
+    Posts []Post
+}
+
+type YearGroup struct {
+    Year   int
+    Link   string
+    Months []MonthGroup
+}
+
+func (m MonthGroup) Count() int {
+    return len(m.Posts)
+}
+
+func (y YearGroup) Count() int {
+    total := 0
+    for _, m := range y.Months {
+        total += m.Count()
+    }
+    return total
+}
+
+// Agrupa los posts por año y mes, del más reciente al más antiguo.
+// Los posts sin fecha no aparecen en el archivo.
+func BuildArchive(posts []Post) []YearGroup {
+    var years []YearGroup
+
+    for _, p := range LatestPosts(posts) {
+        if p.Date.IsZero() {
+            continue
+        }
+        year, month := p.Date.Year(), int(p.Date.Month())
+
+        if len(years) == 0 || years[len(years)-1].Year != year {
+            years = append(years, YearGroup{
+                Year: year,
+                Link: fmt.Sprintf("archive/%d/", year),
+            })
+        }
+        y := &years[len(years)-1]
+
+        if len(y.Months) == 0 || y.Months[len(y.Months)-1].Month != month {
+            y.Months = append(y.Months, MonthGroup{
+                Month: month,
+                Name:  p.Date.MonthName(),
+                Link:  fmt.Sprintf("archive/%d/%02d/", year, month),
+            })
+        }
+        m := &y.Months[len(y.Months)-1]
+        m.Posts = append(m.Posts, p)
+    }
+
+    return years
+}
+
+// Página del archivo a generar: slug relativo a public/archive/ y sus datos
+type archivePage struct {
+    slug    string
+    archive []YearGroup
+    year    *YearGroup
+    month   *MonthGroup
+}
+
+func archivePages(years []YearGroup) []archivePage {
+    pages := []archivePage{{slug: "", archive: years}}
+
+    for i := range years {
+        y := years[i]
+        pages = append(pages, archivePage{
+            slug:    fmt.Sprintf("%d", y.Year),
+            archive: []YearGroup{y},
+            year:    &years[i],
+        })
+
+        for j := range y.Months {
+            m := y.Months[j]
+            soloMes := YearGroup{Year: y.Year, Link: y.Link, Months: []MonthGroup{m}}
+            pages = append(pages, archivePage{
+                slug:    fmt.Sprintf("%d/%02d", y.Year, m.Month),
+                archive: []YearGroup{soloMes},
+                year:    &years[i],
+                month:   &years[i].Months[j],
+            })
+        }
+    }
+    return pages
+}
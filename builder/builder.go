@@ -7,7 +7,7 @@ import (
     "log"
     "time"
 	"bytes"
-	"regexp"
+    "slices"
     "strconv"
 	"strings"
 	"html/template"
@@ -28,6 +28,18 @@ import (
 type Builder struct {
     baseTmpl *template.Template
 	pages map[string]*template.Template
+    shortcodes *Shortcodes
+    site  *Site
+    // Config y hora del build, disponibles desde InitTemplates: los shortcodes
+    // se ejecutan al cargar los posts, antes de que exista site
+    cfg       Config
+    buildTime time.Time
+}
+
+// Opciones de línea de comandos para build y serve
+type BuildOptions struct {
+    Drafts bool // Incluir posts con draft: true
+    Future bool // Incluir posts con fecha futura
 }
 
 type RenderResult struct {
@@ -55,14 +67,37 @@ func copyRoute(fs afero.Fs,src, dst string) error {
 	})
 }
 
-func slugify(s string) string {
-	s = strings.ToLower(s)
-	reg := regexp.MustCompile("[^a-z0-9]+")
-	s = reg.ReplaceAllString(s, "-")
-	return strings.Trim(s, "-")
+// Templates de pages/ que no se generan como página suelta
+var reservedPages = map[string]bool{
+    "post.html":     true,
+    "taxonomy.html": true,
+    "terms.html":    true,
+    "archive.html":  true,
+    "404.html":      true,
+    "section.html":  true,
+    "page.html":     true,
+    "series.html":   true,
+}
+
+// Script de Live Reload para el modo serve
+func injectLiveReload(content []byte) []byte {
+    script := `
+        <script>
+        const ws = new WebSocket("ws://" + window.location.host + "/ws");
+        ws.onmessage = (e) => { if (e.data === "reload") window.location.reload(); };
+        </script>`
+
+    contentStr := string(content)
+
+    if strings.Contains(strings.ToLower(contentStr), "</body>") {
+        // Si existe, reemplazamos normal
+        return []byte(strings.Replace(contentStr, "</body>", script+"</body>", 1))
+    }
+    // Si NO existe (por la minificación), lo pegamos al final
+    return append(content, []byte(script)...)
 }
 
-func RunBuild(fs afero.Fs, isDev bool) {
+func RunBuild(fs afero.Fs, isDev bool, opts BuildOptions) {
     cfg, err := LoadConfig()
     if err != nil {
         log.Fatal(err)
@@ -72,23 +107,83 @@ func RunBuild(fs afero.Fs, isDev bool) {
         cfg.BaseURL = "/"
     }
 
-    err = ConfigYaml()
-    if err != nil {
-        log.Fatal(err)
+    // Completar fechas vacías solo si se activó en config.yaml (ver también "yamblg dates --fix")
+    if cfg.FillDates {
+        if _, err := ConfigYaml(true); err != nil {
+            log.Fatal(err)
+        }
     }
 
-    b := &Builder{}
+    b := &Builder{cfg: cfg, buildTime: time.Now()}
     paginasDetectadas, err := b.InitTemplates()
     if err != nil {
         log.Fatal(err)
     }
 
-    allPosts, err := LoadPosts()
+    // Archivos de data/ para .Site.Data
+    siteData, err := LoadData("data")
+    if err != nil {
+        log.Fatalf("Error cargando data: %v", err)
+    }
+
+    allPosts, err := LoadPosts(cfg, b.shortcodes)
     if err != nil {
         log.Fatalf("Error cargando posts: %v", err)
     }
-    
-    limitePosts := min(len(allPosts), cfg.UseSectionPost.LimitOfPost)
+    allPosts = FilterPosts(allPosts, opts)
+
+    // Links de cada post y página (slug/permalink) y detección de rutas repetidas
+    if err := AssignPermalinks(allPosts, cfg); err != nil {
+        log.Fatalf("Error en permalinks: %v", err)
+    }
+
+    for i := range allPosts {
+        post := &allPosts[i]
+        post.UrlUser = cfg.UserUrl
+        post.Email = cfg.Email
+
+        // Referencias a imágenes y adjuntos de los page bundles
+        resolveBundleLinks(post, cfg.BaseURL)
+    }
+
+    // Páginas sueltas de content/pages/: fuera de listados y feed
+    allPosts, standalonePages := SplitPages(allPosts)
+
+    if err := SortPosts(allPosts, cfg); err != nil {
+        log.Fatalf("config.yaml: %v", err)
+    }
+
+    // Posts relacionados ("Seguir leyendo"), ya con el orden definitivo
+    RelatedPosts(allPosts, cfg)
+
+    // Navegación anterior/siguiente por fecha
+    if err := PrevNext(allPosts, cfg); err != nil {
+        log.Fatalf("config.yaml: %v", err)
+    }
+
+    // Series: .Post.Series en cada parte y una página por serie
+    series := BuildSeries(allPosts)
+
+    // El sitio completo (posts, tags, secciones, archivo, data) para todos los templates
+    site := NewSite(cfg, isDev, b.buildTime, allPosts, standalonePages, siteData)
+    site.Taxonomies = BuildTaxonomies(allPosts)
+    site.Sections = BuildSections(allPosts, cfg)
+    site.Archive = BuildArchive(allPosts)
+    site.Series = series
+    b.site = site
+
+    // Rutas de todo el sitio: páginas sueltas, secciones, tags, archivo, ... no pueden repetirse
+    routes, err := b.siteRoutes(paginasDetectadas)
+    if err != nil {
+        log.Fatalf("Error en rutas: %v", err)
+    }
+
+    // Redirecciones: redirects de config.yaml, aliases de cada post y slugs viejos.
+    // Se validan contra todas las rutas del sitio antes de escribir nada.
+    redirects, err := CollectRedirects(cfg, append(slices.Clone(allPosts), standalonePages...), routes)
+    if err != nil {
+        log.Fatalf("Error en redirecciones: %v", err)
+    }
     
     if !isDev {
     fs.RemoveAll("public")
@@ -110,65 +205,113 @@ func RunBuild(fs afero.Fs, isDev bool) {
                 io.Copy(destino, origen)
             }
         }
-        GenerateSitemap(allPosts, cfg.UserUrl, cfg.BaseURL)
-        GenerateRSS(allPosts, cfg.UserUrl, cfg.BaseURL, cfg.SiteTitle, allPosts[0].Author, cfg.Email)
     }
 
     copyRoute(fs, "assets", "public/assets")
 
-    // Nota: Deberías pasar isDev a BuildPosts si quieres Live Reload en los artículos individuales
-    b.BuildPosts(isDev,fs, cfg.BaseURL, allPosts, cfg.UsePinned.Active, cfg.UserUrl, cfg.Email)
-    
-    PagesData := map[string]any{
-        "BaseURL":      cfg.BaseURL,
-        "Title":         cfg.SiteTitle,
-        "Posts":         allPosts,
-        "ActiveLasted":  cfg.UseSectionPost.Active,
-        "ActivePinned":  cfg.UsePinned.Active,
-        "Latest":        allPosts[:limitePosts],
-        "CantPost":      strconv.Itoa(limitePosts),
+    b.BuildPosts(fs)
+    b.BuildStandalonePages(fs)
+
+    // Sitemap y feed: borradores y programados solo si se pidieron con --drafts / --future
+    if !isDev {
+        publicados := FilterPosts(allPosts, opts)
+        paginasPublicadas := FilterPosts(standalonePages, opts)
+
+        // El autor del feed es el del post más reciente
+        publicados = LatestPosts(publicados)
+        author := ""
+        if len(publicados) > 0 {
+            author = publicados[0].Author
+        }
+        // Las páginas sueltas van al sitemap pero no al feed
+        GenerateSitemap(append(publicados, paginasPublicadas...), cfg.UserUrl, cfg.BaseURL)
+        GenerateRSS(publicados, cfg.UserUrl, cfg.BaseURL, cfg.SiteTitle, author, cfg.Email)
     }
 
+    // Tags y categorías
+    b.BuildTaxonomyPages(fs)
+
+    // Secciones: carpetas de primer nivel de content/
+    b.BuildSectionPages(fs)
+
+    // Archivo cronológico: /archive/, /archive/2026/, /archive/2026/01/
+    b.BuildArchivePages(fs)
+
+    // Series: /series/<slug>/
+    b.BuildSeriesPages(fs)
+
+    // Páginas de pages/ (home, lista-de-posteos, ...) y el 404
+    b.BuildPages(fs, paginasDetectadas)
+    b.Build404(fs)
+
+    WriteRedirects(fs, cfg, redirects)
+
+    fmt.Println("🚀 Sitio generado con éxito")
+}
+
+// Genera cada template suelto de pages/ (paginado si así lo indica config.yaml)
+func (b *Builder) BuildPages(fs afero.Fs, paginasDetectadas []string) {
+    cfg := b.site.Config
+
     for _, nombreArchivo := range paginasDetectadas {
-        if nombreArchivo == "post.html" {
+        if reservedPages[nombreArchivo] || isSectionTemplate(b.site.Sections, nombreArchivo) {
             continue 
         }
 
-        result, err := b.BuildPage(nombreArchivo, PagesData)
-        if err != nil {
-            log.Printf("Error en %s: %v", nombreArchivo, err)
-            continue
+        folderName := strings.TrimSuffix(nombreArchivo, ".html")
+        kind, link := KindPage, folderName+"/"
+        if folderName == "home" || folderName == "index" {
+            kind, link = KindHome, ""
         }
 
-        // --- INYECCIÓN LIVE RELOAD ---
-        if isDev {
-            script := `
-        <script>
-        const ws = new WebSocket("ws://" + window.location.host + "/ws");
-        ws.onmessage = (e) => { if (e.data === "reload") window.location.reload(); };
-        </script>`
+        // Páginas paginadas: home -> /page/2/, lista-de-posteos -> /lista-de-posteos/page/2/
+        paginas := b.paginate(folderName)
+
+        for _, pag := range paginas {
+            data := b.data(b.site.newPage(kind, cfg.SiteTitle, link))
+            if pag != nil {
+                data.Posts = pag.Posts
+                data.Paginator = pag
+                data.Page = b.site.newPage(kind, cfg.SiteTitle, pageURL(folderName, pag.PageNumber))
+                // Los últimos posts solo en la primera página
+                data.ActiveLasted = data.ActiveLasted && pag.PageNumber == 1
+            }
 
-            contentStr := string(result.Content)
-            
-            if strings.Contains(strings.ToLower(contentStr), "</body>") {
-                // Si existe, reemplazamos normal
-                newContent := strings.Replace(contentStr, "</body>", script+"</body>", 1)
-                result.Content = []byte(newContent)
-            } else {
-                // Si NO existe (por la minificación), lo pegamos al final
-                result.Content = append(result.Content, []byte(script)...)
+            result, err := b.render(nombreArchivo, data)
+            if err != nil {
+                log.Printf("Error en %s: %v", nombreArchivo, err)
+                break
             }
-        }
 
-        err = CreateRoute(fs, RoutePublic, "", result)
-        if err != nil {
-            log.Fatal(err)
+            if pag != nil && pag.PageNumber > 1 {
+                err = CreateRoute(fs, RoutePaginated, strconv.Itoa(pag.PageNumber), result)
+            } else {
+                err = CreateRoute(fs, RoutePublic, "", result)
+            }
+            if err != nil {
+                log.Fatal(err)
+            }
         }
         
-        fmt.Printf("✓ Página generada: %s\n", result.FolderName)
+        fmt.Printf("✓ Página generada: %s (%d)\n", folderName, len(paginas))
     }
+}
 
-    fmt.Println("🚀 Sitio generado con éxito")
+// 404 plano en public/404.html (lo que esperan GitHub Pages y la mayoría de los hostings)
+func (b *Builder) Build404(fs afero.Fs) {
+    if _, ok := b.pages["404.html"]; !ok {
+        return
+    }
+
+    result, err := b.render("404.html", b.data(b.site.newPage(Kind404, "Página no encontrada", "404.html")))
+    if err != nil {
+        log.Printf("Error en 404.html: %v", err)
+        return
+    }
+    if err := CreateRoute(fs, RouteFile, "", result); err != nil {
+        log.Fatal(err)
+    }
+    fmt.Println("✓ Página generada: 404")
 }
 
 // Init de templates
@@ -182,7 +325,14 @@ func (b *Builder) InitTemplates() ([]string, error) {
     files = append(files, components...)
     
     var err error
-    b.baseTmpl, err = template.ParseFiles(files...)
+    // Las funciones se registran en la base, así las heredan todos los clones
+    b.baseTmpl, err = template.New(filepath.Base(files[0])).Funcs(b.funcMap()).ParseFiles(files...)
+    if err != nil {
+        return nil, err
+    }
+
+    // Shortcodes para el body de los posts (shortcodes/*.html)
+    b.shortcodes, err = loadShortcodes(b.baseTmpl, "shortcodes")
     if err != nil {
         return nil, err
     }
@@ -216,16 +366,18 @@ func GenerateSitemap(posts []Post, UrlUser string, BaseUrl string) {
         Priority: 1.0,
     })
     
-    layout := "2006-01-02"
-   
     // Añadir tus posts
     for _, p := range posts {
         
-        t, _ := time.Parse(layout, p.Date)
+        // Sin fecha no se informa lastmod
+        var lastMod *time.Time
+        if t := p.LastMod(); !t.IsZero() {
+            lastMod = &t
+        }
 
         sm.Add(&sitemap.URL{
             Loc:        p.FullLink,
-            LastMod:    &t, // Usa tu fecha de creación
+            LastMod:    lastMod, // Usa updated o la fecha de creación
             ChangeFreq: sitemap.Weekly,
         })
     }
@@ -245,13 +397,10 @@ func GenerateRSS(posts []Post, UrlUser string, baseUrl string, descrp string, au
     }
 
 
-    layout := "2006-01-02"
-
     for _, p := range posts {
-        // Parseamos el string a objeto time.Time
-        t, err := time.Parse(layout, p.Date)
-        if err != nil {
-            // Si el YAML no tiene fecha o el formato falla, usamos la hora actual
+        t := p.Date.Time
+        if t.IsZero() {
+            // Si el YAML no tiene fecha usamos la hora actual
             t = time.Now() 
         }
         
@@ -262,6 +411,7 @@ func GenerateRSS(posts []Post, UrlUser string, baseUrl string, descrp string, au
             Description: p.Description,
             Author:      &feeds.Author{Name: p.Author, Email: p.Email},
             Created:     t, // <--- QUITA EL & AQUÍ. RSS usa valor, no puntero.
+            Updated:     p.LastMod(),
         }
         feed.Items = append(feed.Items, item)
     }
@@ -274,7 +424,7 @@ func GenerateRSS(posts []Post, UrlUser string, baseUrl string, descrp string, au
     }
     
     // 2. Definimos la URL de auto-referencia
-    fullFeedURL := UrlUser + baseUrl + "/index.xml"
+    fullFeedURL := strings.TrimSuffix(UrlUser+baseUrl, "/") + "/index.xml"
     
     // 3. Preparamos la etiqueta de auto-referencia obligatoria para Atom
     // Se debe colocar dentro del bloque principal <feed>
@@ -340,58 +490,167 @@ func (b *Builder) BuildPage(contentTemplate string, data any) (RenderResult, err
     }, nil
 }
 
-func (b *Builder) BuildPosts(isDev bool,fs afero.Fs,baseUrl string, allPosts []Post, active bool, userUrl string, emailDir string) {
+func (b *Builder) BuildPosts(fs afero.Fs) {
 	
 	// 3.2 Recorrer y renderizar los posts
-	for i := range allPosts {
-		// Apuntamos al post original para actualizar su Link permanentemente
-		post := &allPosts[i]
-
-		// Nombre de la carpeta dentro de post
-		RouteNamePost := slugify(post.Title)
-        
-        //Definiendo rutas
-        post.UrlUser = userUrl
-		post.Link = "post/" + RouteNamePost + "/"
-        post.FullLink = userUrl + baseUrl + "/" + post.Link
-
-        //Email
-        post.Email = emailDir
+	for i := range b.site.Posts {
+		// Apuntamos al post original (Link y FullLink ya vienen resueltos por AssignPermalinks)
+		post := &b.site.Posts[i]
 
 		// Preparamos los datos para el template
-		postData := map[string]any{
-			"BaseURL": baseUrl,
-			"Post": post, // Pasamos el puntero o el valor (*post)
-            "ActivePinned": active,
-		}
+		postData := b.data(b.site.postPage(KindPost, post))
+		postData.Post = post
 		
-		// Generamos el archivo físico (ej: public/post/mi-titulo.html)
-		PostResult, err := b.BuildPage("post.html", postData)
+		PostResult, err := b.render(b.postTemplate(*post), postData)
 		if err != nil {
 			fmt.Printf("Error renderizando post: %v\n", err)
 			continue // Salta al siguiente post si este falla
 		}
 
-        if isDev {
-            script := `
-        <script>
-        const ws = new WebSocket("ws://" + window.location.host + "/ws");
-        ws.onmessage = (e) => { if (e.data === "reload") window.location.reload(); };
-        </script>`
+		// Generamos el archivo físico en la ruta del permalink (ej: public/post/mi-titulo/index.html)
+		if err := CreateRoute(fs, RoutePermalink, post.Link, PostResult); err != nil {
+			log.Fatal(err)
+		}
 
-            contentStr := string(PostResult.Content)
-            
-            if strings.Contains(strings.ToLower(contentStr), "</body>") {
-                // Si existe, reemplazamos normal
-                newContent := strings.Replace(contentStr, "</body>", script+"</body>", 1)
-                PostResult.Content = []byte(newContent)
-            } else {
-                // Si NO existe (por la minificación), lo pegamos al final
-                PostResult.Content = append(PostResult.Content, []byte(script)...)
+		// Archivos del page bundle al lado del index.html
+		if err := copyBundle(fs, *post); err != nil {
+			log.Printf("Error copiando archivos de %s: %v", post.File, err)
+		}
+        fmt.Printf("✓ Página generada: %s\n", post.Link)
+	}
+}
+
+// Genera public/<taxonomia>/index.html y public/<taxonomia>/<slug>/index.html
+func (b *Builder) BuildTaxonomyPages(fs afero.Fs) {
+    if _, ok := b.pages["taxonomy.html"]; !ok {
+        return
+    }
+
+    for _, name := range taxonomyNames {
+        tax := b.site.Taxonomies[name]
+
+        for _, term := range tax.Terms {
+            termData := b.data(b.site.newPage(KindTaxonomy, term.Name, term.Link))
+            termData.Taxonomy = tax
+            termData.Term = term
+            termData.Posts = term.Posts
+
+            result, err := b.render("taxonomy.html", termData)
+            if err != nil {
+                log.Printf("Error en %s/%s: %v", name, term.Slug, err)
+                continue
+            }
+
+            result.FolderName = name
+            if err := CreateRoute(fs, RouteTaxonomy, term.Slug, result); err != nil {
+                log.Fatal(err)
             }
         }
 
-		CreateRoute(fs,RoutePost, RouteNamePost, PostResult)
-        fmt.Printf("✓ Página generada: %s\n", RouteNamePost)
-	}
-}
\ No newline at end of file
+        if _, ok := b.pages["terms.html"]; !ok {
+            continue
+        }
+
+        indexData := b.data(b.site.newPage(KindTerms, name, tax.Link))
+        indexData.Taxonomy = tax
+
+        result, err := b.render("terms.html", indexData)
+        if err != nil {
+            log.Printf("Error en %s: %v", name, err)
+            continue
+        }
+
+        result.FolderName = name
+        if err := CreateRoute(fs, RouteTaxonomy, "", result); err != nil {
+            log.Fatal(err)
+        }
+        fmt.Printf("✓ Página generada: %s (%d términos)\n", name, len(tax.Terms))
+    }
+}
+
+// Genera public/archive/index.html y una página por año y por mes
+func (b *Builder) BuildArchivePages(fs afero.Fs) {
+    if _, ok := b.pages["archive.html"]; !ok {
+        return
+    }
+
+    years := b.site.Archive
+    for _, page := range archivePages(years) {
+        data := b.data(b.site.newPage(KindArchive, "Archivo", "archive/"+page.slug))
+        data.Archive = page.archive
+        data.Years = years
+        data.Year = page.year
+        data.Month = page.month
+
+        result, err := b.render("archive.html", data)
+        if err != nil {
+            log.Printf("Error en archive/%s: %v", page.slug, err)
+            continue
+        }
+
+        result.FolderName = "archive"
+        if err := CreateRoute(fs, RouteArchive, page.slug, result); err != nil {
+            log.Fatal(err)
+        }
+    }
+    fmt.Printf("✓ Página generada: archive (%d años)\n", len(years))
+}
+
+// Genera public/<seccion>/index.html con el listado de cada sección
+func (b *Builder) BuildSectionPages(fs afero.Fs) {
+    if _, ok := b.pages["section.html"]; !ok {
+        return
+    }
+
+    for _, section := range b.site.Sections {
+        data := b.data(b.site.newPage(KindSection, section.Title, section.Link))
+        data.Section = section
+        data.Posts = section.Posts
+
+        result, err := b.render("section.html", data)
+        if err != nil {
+            log.Printf("Error en sección %s: %v", section.Name, err)
+            continue
+        }
+
+        result.FolderName = section.Name
+        if err := CreateRoute(fs, RouteTaxonomy, "", result); err != nil {
+            log.Fatal(err)
+        }
+        fmt.Printf("✓ Página generada: %s (%d posts)\n", section.Name, len(section.Posts))
+    }
+}
+
+// Genera public/series/<slug>/index.html con las partes de cada serie
+func (b *Builder) BuildSeriesPages(fs afero.Fs) {
+    if _, ok := b.pages["series.html"]; !ok {
+        return
+    }
+
+    for _, s := range b.site.Series {
+        data := b.data(b.site.newPage(KindSeries, s.Name, s.Link))
+        data.Series = s
+
+        result, err := b.render("series.html", data)
+        if err != nil {
+            log.Printf("Error en serie %s: %v", s.Name, err)
+            continue
+        }
+
+        result.FolderName = seriesBase
+        if err := CreateRoute(fs, RouteTaxonomy, s.Slug, result); err != nil {
+            log.Fatal(err)
+        }
+        fmt.Printf("✓ Página generada: %s (%d partes)\n", s.Link, len(s.Posts))
+    }
+}
+
+// pages/<seccion>.html es el template de los posts de esa sección, no una página suelta
+func isSectionTemplate(sections []*Section, nombreArchivo string) bool {
+    for _, s := range sections {
+        if s.Name+".html" == nombreArchivo {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,110 @@
+package builder
+
+import (
+    "io"
+    "os"
+    "path/filepath"
+    "regexp"
+    "strings"
+
+    "github.com/spf13/afero"
+)
+
+// Un post puede ser una carpeta (page bundle): content/mi-post/index.yaml
+// junto con sus imágenes y adjuntos, que se copian al lado del HTML generado.
+var bundleIndexNames = []string{"index.yaml", "index.yml"}
+
+// Devuelve la ruta del index.yaml de la carpeta, o "" si no es un bundle
+func bundleIndex(dir string) string {
+    for _, name := range bundleIndexNames {
+        path := filepath.Join(dir, name)
+        if info, err := os.Stat(path); err == nil && !info.IsDir() {
+            return path
+        }
+    }
+    return ""
+}
+
+// Indica si el archivo está dentro de un bundle y no es su index.yaml
+// (por ejemplo content/mi-post/datos.yaml), o sea que no es un post.
+func isBundleResource(path string) bool {
+    dir := filepath.Dir(path)
+    for dir != "." && dir != string(filepath.Separator) && filepath.Base(dir) != "content" {
+        if index := bundleIndex(dir); index != "" {
+            return filepath.Clean(index) != filepath.Clean(path)
+        }
+        dir = filepath.Dir(dir)
+    }
+    return false
+}
+
+// Lista los archivos del bundle (rutas relativas a la carpeta), sin el index.yaml
+func bundleResources(dir string, index string) ([]string, error) {
+    var resources []string
+    err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+        if err != nil {
+            return err
+        }
+        if info.IsDir() || filepath.Clean(path) == filepath.Clean(index) {
+            return nil
+        }
+        rel, err := filepath.Rel(dir, path)
+        if err != nil {
+            return err
+        }
+        resources = append(resources, filepath.ToSlash(rel))
+        return nil
+    })
+    return resources, err
+}
+
+var bundleRefReg = regexp.MustCompile(`(src|href|srcset|poster)=(["'])([^"']+)(["'])`)
+
+// Reescribe las referencias relativas a archivos del bundle ("foto.jpg", "./datos.csv")
+// como rutas absolutas del post, así funcionan también fuera de la página del post.
+func resolveBundleLinks(p *Post, baseURL string) {
+    if len(p.Resources) == 0 {
+        return
+    }
+
+    recursos := make(map[string]bool, len(p.Resources))
+    for _, r := range p.Resources {
+        recursos[r] = true
+    }
+
+    p.Body = bundleRefReg.ReplaceAllStringFunc(p.Body, func(attr string) string {
+        m := bundleRefReg.FindStringSubmatch(attr)
+        ref := strings.TrimPrefix(m[3], "./")
+        if !recursos[ref] {
+            return attr
+        }
+        return m[1] + "=" + m[2] + baseURL + p.Link + ref + m[4]
+    })
+}
+
+// Copia los archivos del bundle a public/<link>/
+func copyBundle(fs afero.Fs, p Post) error {
+    for _, r := range p.Resources {
+        dst := filepath.Join("public", filepath.FromSlash(p.Link), filepath.FromSlash(r))
+        if err := fs.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+            return err
+        }
+
+        src, err := os.Open(filepath.Join(p.BundleDir, filepath.FromSlash(r)))
+        if err != nil {
+            return err
+        }
+        out, err := fs.Create(dst)
+        if err != nil {
+            src.Close()
+            return err
+        }
+        _, err = io.Copy(out, src)
+        src.Close()
+        out.Close()
+        if err != nil {
+            return err
+        }
+    }
+    return nil
+}
@@ -19,6 +19,34 @@ type Config struct {
 	BaseURL   string `yaml:"baseUrl"`
 	SiteTitle string `yaml:"siteTitle"`
     Email     string `yaml:"email"`
+    DefaultFormat string `yaml:"defaultFormat"`
+    SummaryLength int    `yaml:"summaryLength"`
+    Highlight struct {
+		Active      bool   `yaml:"active"`
+		LineNumbers bool   `yaml:"lineNumbers"`
+	} `yaml:"highlight"`
+    Locale    string `yaml:"locale"`
+    FillDates bool   `yaml:"fillDates"`
+    Permalink string `yaml:"permalink"`
+    LegacySlugRedirects bool `yaml:"legacySlugRedirects"`
+    Redirects map[string]string `yaml:"redirects"`
+    Sections  map[string]SectionConfig `yaml:"sections"`
+    RedirectFiles struct {
+		Netlify     bool   `yaml:"netlify"`
+		Nginx       bool   `yaml:"nginx"`
+	} `yaml:"redirectFiles"`
+    Related struct {
+		Active      bool   `yaml:"active"`
+		Count       int    `yaml:"count"`
+		Weights     struct {
+			Tags    float64 `yaml:"tags"`
+			Terms   float64 `yaml:"terms"`
+			Date    float64 `yaml:"date"`
+		} `yaml:"weights"`
+	} `yaml:"related"`
+    PrevNext struct {
+		Within      string `yaml:"within"`
+	} `yaml:"prevNext"`
 	UseSectionPost struct {
 		Active      bool   `yaml:"active"`
 		LimitOfPost int    `yaml:"limitOfPost"`
@@ -26,6 +54,20 @@ type Config struct {
     UsePinned struct {
 		Active      bool   `yaml:"active"`
 	} `yaml:"usePinned"`
+    Pagination struct {
+		PageSize    int      `yaml:"pageSize"`
+		Pages       []string `yaml:"pages"`
+	} `yaml:"pagination"`
+    SortPosts struct {
+		By          string `yaml:"by"`
+		Order       string `yaml:"order"`
+		PinnedFirst bool   `yaml:"pinnedFirst"`
+	} `yaml:"sortPosts"`
+}
+
+// URL absoluta de una ruta relativa a BaseURL
+func (cfg Config) AbsURL(link string) string {
+    return strings.TrimSuffix(cfg.UserUrl+cfg.BaseURL, "/") + "/" + strings.TrimPrefix(link, "/")
 }
 
 func LoadConfig() (Config, error){
@@ -42,60 +84,113 @@ func LoadConfig() (Config, error){
 	return config, err
 }
 
-func ConfigYaml() error {
+// Busca los posts sin fecha. Con fix = true les agrega la fecha de modificación del archivo.
+// Devuelve las rutas de los archivos sin fecha (corregidos o no).
+func ConfigYaml(fix bool) ([]string, error) {
 	contentDir := "./content"
 
 	// Verificamos si la carpeta existe
 	if _, err := os.Stat(contentDir); os.IsNotExist(err) {
-		return fmt.Errorf("el directorio %s no existe", contentDir)
+		return nil, fmt.Errorf("el directorio %s no existe", contentDir)
 	}
 
-	return filepath.Walk(contentDir, func(path string, info os.FileInfo, err error) error {
+	var sinFecha []string
+	err := filepath.Walk(contentDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
+		// Las páginas sueltas de content/pages/ no usan fecha
+		if info.IsDir() {
+			if rel, _ := filepath.Rel(contentDir, path); rel == pagesSection {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
 		// Filtrar solo archivos YAML
-		if !info.IsDir() && (strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml")) {
-			err := fillDateIfEmpty(path, info)
+		// Los YAML dentro de un page bundle (salvo index.yaml) son adjuntos, no posts
+		if !info.IsDir() && (strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml")) && !isBundleResource(path) {
+			missing, err := fillDateIfEmpty(path, info, fix)
 			if err != nil {
 				fmt.Printf("Error procesando %s: %v\n", path, err)
 			}
+			if missing {
+				sinFecha = append(sinFecha, path)
+			}
 		}
 		return nil
 	})
+	return sinFecha, err
 }
 
-// Función interna (no exportada) para la lógica de edición
-func fillDateIfEmpty(path string, info os.FileInfo) error {
+// Función interna (no exportada) para la lógica de edición.
+// Se trabaja sobre el árbol de nodos solo para ubicar la línea: el resto del archivo
+// (orden de claves, comentarios, estilo del body) queda tal cual.
+func fillDateIfEmpty(path string, info os.FileInfo, fix bool) (bool, error) {
 	content, err := os.ReadFile(path)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	// Usamos un mapa para mantener la estructura flexible
-	var data map[string]interface{}
-	if err := yaml.Unmarshal(content, &data); err != nil {
-		return err
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return false, err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return false, nil
+	}
+	root := doc.Content[0]
+	if len(root.Content) == 0 || root.Style&yaml.FlowStyle != 0 {
+		return false, nil
 	}
 
 	// Lógica de validación de fecha
-	dateVal, exists := data["date"]
-	if !exists || dateVal == "" || dateVal == nil {
-		// info.ModTime() es agnóstico y representa la creación si el archivo es nuevo
-		data["date"] = info.ModTime().Format("02-01-2006")
-
-		// Serializar de nuevo a YAML
-		newData, err := yaml.Marshal(&data)
-		if err != nil {
-			return err
+	var dateKey, dateVal *yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "date" {
+			dateKey, dateVal = root.Content[i], root.Content[i+1]
+			break
 		}
+	}
+	// "date:", "date: ~" y "date: null" cuentan como sin fecha
+	if dateVal != nil && dateVal.Value != "" && dateVal.Tag != "!!null" {
+		return false, nil
+	}
+	if !fix {
+		return true, nil
+	}
 
-		// Sobrescribir el archivo con la fecha incluida
-		return os.WriteFile(path, newData, 0644)
+	newline := "\n"
+	if strings.Contains(string(content), "\r\n") {
+		newline = "\r\n"
+	}
+	lines := strings.SplitAfter(string(content), "\n")
+
+	// info.ModTime() es agnóstico y representa la creación si el archivo es nuevo
+	dateLine := fmt.Sprintf("date: %q", info.ModTime().Format("2006-01-02"))
+
+	if dateKey != nil {
+		// "date:" o "date: """ -> se reemplaza solo esa línea, conservando el comentario
+		idx := dateKey.Line - 1
+		comment := dateVal.LineComment
+		if comment == "" {
+			comment = dateKey.LineComment
+		}
+		if comment != "" {
+			dateLine += " " + comment
+		}
+		lines[idx] = strings.Repeat(" ", dateKey.Column-1) + dateLine + newline
+	} else {
+		// Sin clave date -> se inserta antes de la primera clave
+		first := root.Content[0]
+		idx := first.Line - 1
+		line := strings.Repeat(" ", first.Column-1) + dateLine + newline
+		lines = append(lines[:idx], append([]string{line}, lines[idx:]...)...)
 	}
 
-	return nil
+	// Sobrescribir el archivo con la fecha incluida
+	return true, os.WriteFile(path, []byte(strings.Join(lines, "")), info.Mode().Perm())
 }
 
 func MinifyCSS(fs afero.Fs) {
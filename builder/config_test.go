@@ -0,0 +1,58 @@
+package builder
+
+import (
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func TestFillDateIfEmpty(t *testing.T) {
+    mod := time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC)
+
+    tests := []struct {
+        name    string
+        in      string
+        want    string
+        missing bool
+    }{
+        {"con fecha", "title: a\ndate: \"2025-01-01\"\n", "title: a\ndate: \"2025-01-01\"\n", false},
+        {"sin clave", "title: a\nbody: |\n  hola\n", "date: \"2025-03-04\"\ntitle: a\nbody: |\n  hola\n", true},
+        {"valor vacío", "title: a\ndate:\nbody: b\n", "title: a\ndate: \"2025-03-04\"\nbody: b\n", true},
+        {"texto vacío", "title: a\ndate: \"\"\n", "title: a\ndate: \"2025-03-04\"\n", true},
+        {"null", "title: a\ndate: null\n", "title: a\ndate: \"2025-03-04\"\n", true},
+        {"tilde", "title: a\ndate: ~\n", "title: a\ndate: \"2025-03-04\"\n", true},
+        {"comentario", "title: a\ndate: \"\" # completar\nbody: b\n", "title: a\ndate: \"2025-03-04\" # completar\nbody: b\n", true},
+        {"crlf", "title: a\r\ndate:\r\nbody: b\r\n", "title: a\r\ndate: \"2025-03-04\"\r\nbody: b\r\n", true},
+        {"crlf sin clave", "title: a\r\nbody: b\r\n", "date: \"2025-03-04\"\r\ntitle: a\r\nbody: b\r\n", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            path := filepath.Join(t.TempDir(), "post.yaml")
+            if err := os.WriteFile(path, []byte(tt.in), 0644); err != nil {
+                t.Fatal(err)
+            }
+            if err := os.Chtimes(path, mod, mod); err != nil {
+                t.Fatal(err)
+            }
+            info, err := os.Stat(path)
+            if err != nil {
+                t.Fatal(err)
+            }
+
+            missing, err := fillDateIfEmpty(path, info, true)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if missing != tt.missing {
+                t.Errorf("missing = %v, se esperaba %v", missing, tt.missing)
+            }
+
+            got, _ := os.ReadFile(path)
+            if string(got) != tt.want {
+                t.Errorf("resultado:\n%q\nse esperaba:\n%q", got, tt.want)
+            }
+        })
+    }
+}
@@ -0,0 +1,139 @@
+package builder
+
+import (
+    "encoding/csv"
+    "encoding/json"
+    "fmt"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "strings"
+
+    "github.com/BurntSushi/toml"
+    "gopkg.in/yaml.v3"
+)
+
+// Carga los archivos de data/ en un árbol: data/menu.yaml -> .Site.Data.menu,
+// data/social/links.json -> .Site.Data.social.links.
+// Los CSV se cargan como lista de filas, usando la primera fila como nombres de columna.
+func LoadData(dir string) (map[string]any, error) {
+    data := make(map[string]any)
+
+    if _, err := os.Stat(dir); os.IsNotExist(err) {
+        return data, nil
+    }
+
+    err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+        if err != nil {
+            return err
+        }
+        if d.IsDir() {
+            return nil
+        }
+
+        ext := strings.ToLower(filepath.Ext(path))
+        value, err := decodeDataFile(path, ext)
+        if err != nil {
+            return fmt.Errorf("error parseando %s: %v", path, err)
+        }
+        if value == nil {
+            // Extensión no soportada (README, imágenes, etc.)
+            return nil
+        }
+
+        rel, _ := filepath.Rel(dir, path)
+        keys := strings.Split(filepath.ToSlash(strings.TrimSuffix(rel, filepath.Ext(rel))), "/")
+
+        // Bajamos por las carpetas creando los mapas intermedios
+        nodo := data
+        for _, k := range keys[:len(keys)-1] {
+            hijo, ok := nodo[k].(map[string]any)
+            if !ok {
+                if _, existe := nodo[k]; existe {
+                    return fmt.Errorf("%s: la clave %q ya existe como archivo", path, k)
+                }
+                hijo = make(map[string]any)
+                nodo[k] = hijo
+            }
+            nodo = hijo
+        }
+
+        last := keys[len(keys)-1]
+        if _, existe := nodo[last]; existe {
+            return fmt.Errorf("%s: la clave %q ya está definida por otro archivo", path, last)
+        }
+        nodo[last] = value
+        return nil
+    })
+
+    return data, err
+}
+
+func decodeDataFile(path string, ext string) (any, error) {
+    var value any
+
+    switch ext {
+    case ".yaml", ".yml":
+        content, err := os.ReadFile(path)
+        if err != nil {
+            return nil, err
+        }
+        if err := yaml.Unmarshal(content, &value); err != nil {
+            return nil, err
+        }
+    case ".json":
+        content, err := os.ReadFile(path)
+        if err != nil {
+            return nil, err
+        }
+        if err := json.Unmarshal(content, &value); err != nil {
+            return nil, err
+        }
+    case ".toml":
+        var m map[string]any
+        if _, err := toml.DecodeFile(path, &m); err != nil {
+            return nil, err
+        }
+        value = m
+    case ".csv":
+        return decodeCSV(path)
+    default:
+        return nil, nil
+    }
+
+    // Un archivo vacío se carga como mapa vacío para que no se ignore
+    if value == nil {
+        value = map[string]any{}
+    }
+    return value, nil
+}
+
+func decodeCSV(path string) (any, error) {
+    f, err := os.Open(path)
+    if err != nil {
+        return nil, err
+    }
+    defer f.Close()
+
+    rows, err := csv.NewReader(f).ReadAll()
+    if err != nil {
+        return nil, err
+    }
+
+    filas := []map[string]string{}
+    if len(rows) == 0 {
+        return filas, nil
+    }
+
+    header := rows[0]
+    for _, row := range rows[1:] {
+        fila := make(map[string]string, len(header))
+        for i, col := range header {
+            if i < len(row) {
+                fila[strings.TrimSpace(col)] = row[i]
+            }
+        }
+        filas = append(filas, fila)
+    }
+    return filas, nil
+}
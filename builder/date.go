@@ -0,0 +1,129 @@
+package builder
+
+import (
+    "fmt"
+    "strings"
+    "time"
+
+    "gopkg.in/yaml.v3"
+)
+
+// Formatos aceptados en date/updated: ISO 8601 y el legado DD-MM-YYYY
+var dateLayouts = []string{
+    time.RFC3339,
+    "2006-01-02T15:04:05",
+    "2006-01-02 15:04:05",
+    "2006-01-02 15:04",
+    "2006-01-02",
+    "02-01-2006",
+}
+
+// Fecha de un post. Guarda el texto original y el valor ya parseado.
+// En los templates: {{ .Date }}, {{ .Date.Long }}, {{ .Date.ISO }}, {{ .Date.Format "2006" }}
+type Date struct {
+    time.Time
+    Raw    string
+    locale string
+}
+
+func ParseDate(value string) (Date, error) {
+    value = strings.TrimSpace(value)
+    if value == "" {
+        return Date{}, nil
+    }
+
+    for _, layout := range dateLayouts {
+        if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+            return Date{Time: t, Raw: value}, nil
+        }
+    }
+    return Date{}, fmt.Errorf("fecha inválida %q (usar AAAA-MM-DD o DD-MM-AAAA)", value)
+}
+
+func (d *Date) UnmarshalYAML(node *yaml.Node) error {
+    parsed, err := ParseDate(node.Value)
+    if err != nil {
+        return fmt.Errorf("línea %d: %v", node.Line, err)
+    }
+    *d = parsed
+    return nil
+}
+
+func (d Date) MarshalYAML() (any, error) {
+    return d.Raw, nil
+}
+
+// Idioma usado por Long, Short y String
+func (d Date) WithLocale(locale string) Date {
+    d.locale = locale
+    return d
+}
+
+type dateLocale struct {
+    months []string
+    short  string
+    long   func(t time.Time, months []string) string
+}
+
+var dateLocales = map[string]dateLocale{
+    "es": {
+        months: []string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio",
+            "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+        short: "02-01-2006",
+        long: func(t time.Time, months []string) string {
+            return fmt.Sprintf("%d de %s de %d", t.Day(), months[t.Month()-1], t.Year())
+        },
+    },
+    "en": {
+        months: []string{"January", "February", "March", "April", "May", "June", "July",
+            "August", "September", "October", "November", "December"},
+        short: "01/02/2006",
+        long: func(t time.Time, months []string) string {
+            return fmt.Sprintf("%s %d, %d", months[t.Month()-1], t.Day(), t.Year())
+        },
+    },
+}
+
+func (d Date) loc() dateLocale {
+    if l, ok := dateLocales[strings.ToLower(d.locale)]; ok {
+        return l
+    }
+    return dateLocales["es"]
+}
+
+// "03-01-2026" (es) / "01/03/2026" (en)
+func (d Date) Short() string {
+    if d.IsZero() {
+        return ""
+    }
+    return d.Time.Format(d.loc().short)
+}
+
+// "3 de enero de 2026" (es) / "January 3, 2026" (en)
+func (d Date) Long() string {
+    if d.IsZero() {
+        return ""
+    }
+    l := d.loc()
+    return l.long(d.Time, l.months)
+}
+
+// Nombre del mes en el idioma del sitio
+func (d Date) MonthName() string {
+    if d.IsZero() {
+        return ""
+    }
+    return d.loc().months[d.Month()-1]
+}
+
+// "2026-01-03", para atributos datetime y metadatos
+func (d Date) ISO() string {
+    if d.IsZero() {
+        return ""
+    }
+    return d.Time.Format("2006-01-02")
+}
+
+func (d Date) String() string {
+    return d.Short()
+}
@@ -0,0 +1,462 @@
+package builder
+
+import (
+    "encoding/json"
+    "fmt"
+    "html"
+    "html/template"
+    "reflect"
+    "regexp"
+    "sort"
+    "strings"
+    "time"
+    "unicode/utf8"
+)
+
+// Funciones disponibles en todos los templates (layout, componentes y pages/).
+//
+// URLs
+//   absURL "index.xml"            -> https://usuario.github.io/Blog/index.xml
+//   relURL "assets/logo.png"      -> /Blog/assets/logo.png
+// Fechas
+//   dateFormat "02/01/2006" .Date -> formatea Date, time.Time o un texto de fecha
+//   now                           -> hora del build
+// Texto
+//   truncate 140 .Description     -> corta en N caracteres y agrega "…"
+//   plainify .Body                -> quita las etiquetas HTML
+//   markdownify "**hola**"        -> renderiza Markdown
+//   slugify "Año nuevo"           -> ano-nuevo
+//   safeHTML / safeURL            -> marca el texto como seguro (sin escapar)
+//   jsonify .Site.Data.menu       -> JSON (ej: para <script type="application/ld+json">)
+//   readingTime .Post             -> minutos de lectura (acepta un post o un texto)
+//   wordCount .Post               -> cantidad de palabras
+// Colecciones (posts, términos, data...)
+//   where .Site.Posts "Section" "notas"      -> filtra por campo (operadores: == != > >= < <= in)
+//   where .Site.Posts "Tags" "in" "go"       -> posts con el tag "go"
+//   sortBy .Site.Posts "Title" "asc"         -> ordena por campo (asc/desc)
+//   groupBy .Site.Posts "Section"            -> []Group{Key, Items}
+//   first 5 .Site.Posts / last 3 .Posts      -> primeros/últimos N
+//
+// Dependen solo de b.cfg y b.buildTime (no de b.site) porque los shortcodes
+// también las usan y se ejecutan mientras se cargan los posts.
+func (b *Builder) funcMap() template.FuncMap {
+    return template.FuncMap{
+        "absURL": func(link string) string {
+            if isAbsoluteURL(link) {
+                return link
+            }
+            return b.cfg.AbsURL(link)
+        },
+        "relURL": func(link string) string {
+            if isAbsoluteURL(link) {
+                return link
+            }
+            return b.cfg.BaseURL + strings.TrimPrefix(link, "/")
+        },
+        "dateFormat":  dateFormat,
+        "now":         func() time.Time { return b.buildTime },
+        "truncate":    truncate,
+        "plainify":    plainify,
+        "markdownify": markdownify,
+        "slugify":     slugify,
+        "safeHTML":    func(s string) template.HTML { return template.HTML(s) },
+        "safeURL":     func(s string) template.URL { return template.URL(s) },
+        "jsonify":     jsonify,
+        "readingTime": readingTime,
+        "wordCount":   wordCount,
+        "where":       where,
+        "sortBy":      sortBy,
+        "groupBy":     groupBy,
+        "first":       first,
+        "last":        last,
+    }
+}
+
+func toTime(v any) (time.Time, error) {
+    switch t := v.(type) {
+    case Date:
+        return t.Time, nil
+    case *Date:
+        return t.Time, nil
+    case time.Time:
+        return t, nil
+    case string:
+        d, err := ParseDate(t)
+        return d.Time, err
+    }
+    return time.Time{}, fmt.Errorf("dateFormat: no se puede usar %T como fecha", v)
+}
+
+func dateFormat(layout string, v any) (string, error) {
+    t, err := toTime(v)
+    if err != nil || t.IsZero() {
+        return "", err
+    }
+    return t.Format(layout), nil
+}
+
+func toText(v any) string {
+    switch t := v.(type) {
+    case string:
+        return t
+    case template.HTML:
+        return string(t)
+    case Post:
+        return t.Body
+    case *Post:
+        return t.Body
+    }
+    return fmt.Sprint(v)
+}
+
+func truncate(n int, v any) string {
+    s := strings.TrimSpace(toText(v))
+    if utf8.RuneCountInString(s) <= n {
+        return s
+    }
+    runes := []rune(s)
+    corte := string(runes[:n])
+    // Cortamos en el último espacio para no partir palabras
+    if i := strings.LastIndex(corte, " "); i > n/2 {
+        corte = corte[:i]
+    }
+    return strings.TrimRight(corte, " ,.;:") + "…"
+}
+
+var tagReg = regexp.MustCompile(`<[^>]*>`)
+
+func plainify(v any) string {
+    text := html.UnescapeString(tagReg.ReplaceAllString(toText(v), " "))
+    return strings.Join(strings.Fields(text), " ")
+}
+
+func markdownify(v any) (template.HTML, error) {
+    out, err := renderBody(toText(v), FormatMarkdown)
+    return template.HTML(out), err
+}
+
+func jsonify(v any) (template.JS, error) {
+    out, err := json.Marshal(v)
+    return template.JS(out), err
+}
+
+// Palabras por minuto para el tiempo de lectura
+const wordsPerMinute = 200
+
+func wordCount(v any) int {
+    return len(strings.Fields(plainify(v)))
+}
+
+func readingTime(v any) int {
+    words := wordCount(v)
+    if words == 0 {
+        return 0
+    }
+    return max(1, (words+wordsPerMinute-1)/wordsPerMinute)
+}
+
+// Valor de un campo o método sin argumentos (ej: "Title", "Date", "Section")
+func fieldValue(item reflect.Value, key string) (reflect.Value, error) {
+    for item.Kind() == reflect.Interface {
+        item = item.Elem()
+    }
+
+    if m := item.MethodByName(key); m.IsValid() && m.Type().NumIn() == 0 && m.Type().NumOut() >= 1 {
+        return m.Call(nil)[0], nil
+    }
+
+    base := item
+    for base.Kind() == reflect.Pointer {
+        if base.IsNil() {
+            return reflect.Value{}, nil
+        }
+        base = base.Elem()
+    }
+
+    switch base.Kind() {
+    case reflect.Struct:
+        if f := base.FieldByName(key); f.IsValid() {
+            return f, nil
+        }
+    case reflect.Map:
+        if v := base.MapIndex(reflect.ValueOf(key)); v.IsValid() {
+            return v, nil
+        }
+        return reflect.Value{}, nil
+    }
+    return reflect.Value{}, fmt.Errorf("no existe el campo %q en %s", key, item.Type())
+}
+
+func toSlice(coll any) (reflect.Value, error) {
+    v := reflect.ValueOf(coll)
+    for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+        v = v.Elem()
+    }
+    if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+        return reflect.Value{}, fmt.Errorf("se esperaba una lista y se recibió %T", coll)
+    }
+    return v, nil
+}
+
+// Compara dos valores simples: <0, 0, >0. ok = false si no son comparables.
+func compareValues(a, b reflect.Value) (int, bool) {
+    if !a.IsValid() || !b.IsValid() {
+        return 0, false
+    }
+    for a.Kind() == reflect.Interface || a.Kind() == reflect.Pointer {
+        if a.IsNil() {
+            return 0, false
+        }
+        a = a.Elem()
+    }
+    for b.Kind() == reflect.Interface || b.Kind() == reflect.Pointer {
+        if b.IsNil() {
+            return 0, false
+        }
+        b = b.Elem()
+    }
+
+    if ta, err := toTime(a.Interface()); err == nil && a.Kind() == reflect.Struct {
+        if tb, err := toTime(b.Interface()); err == nil {
+            return ta.Compare(tb), true
+        }
+    }
+
+    switch {
+    case a.CanInt() && b.CanInt():
+        return cmpOrdered(a.Int(), b.Int()), true
+    case a.CanFloat() || b.CanFloat():
+        fa, oka := toFloat(a)
+        fb, okb := toFloat(b)
+        if oka && okb {
+            return cmpOrdered(fa, fb), true
+        }
+    case a.Kind() == reflect.String && b.Kind() == reflect.String:
+        return strings.Compare(a.String(), b.String()), true
+    case a.Kind() == reflect.Bool && b.Kind() == reflect.Bool:
+        switch {
+        case a.Bool() == b.Bool():
+            return 0, true
+        case a.Bool():
+            return 1, true
+        }
+        return -1, true
+    }
+    return 0, false
+}
+
+// Valor ausente: campo inexistente en un map o puntero/interfaz nil
+func isMissing(v reflect.Value) bool {
+    for v.IsValid() && (v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer) {
+        if v.IsNil() {
+            return true
+        }
+        v = v.Elem()
+    }
+    return !v.IsValid()
+}
+
+func toFloat(v reflect.Value) (float64, bool) {
+    switch {
+    case v.CanFloat():
+        return v.Float(), true
+    case v.CanInt():
+        return float64(v.Int()), true
+    case v.CanUint():
+        return float64(v.Uint()), true
+    }
+    return 0, false
+}
+
+func cmpOrdered[T int64 | float64](a, b T) int {
+    switch {
+    case a < b:
+        return -1
+    case a > b:
+        return 1
+    }
+    return 0
+}
+
+// where coll "Campo" valor | where coll "Campo" "operador" valor
+func where(coll any, key string, args ...any) (any, error) {
+    op, value := "==", any(nil)
+    switch len(args) {
+    case 1:
+        value = args[0]
+    case 2:
+        o, ok := args[0].(string)
+        if !ok {
+            return nil, fmt.Errorf("where: el operador debe ser un texto")
+        }
+        op, value = o, args[1]
+    default:
+        return nil, fmt.Errorf("where: se esperaba \"Campo\" valor o \"Campo\" \"operador\" valor")
+    }
+
+    items, err := toSlice(coll)
+    if err != nil {
+        return nil, fmt.Errorf("where: %v", err)
+    }
+
+    out := reflect.MakeSlice(reflect.SliceOf(items.Type().Elem()), 0, items.Len())
+    want := reflect.ValueOf(value)
+
+    for i := 0; i < items.Len(); i++ {
+        item := items.Index(i)
+        got, err := fieldValue(item, key)
+        if err != nil {
+            return nil, fmt.Errorf("where: %v", err)
+        }
+        if !got.IsValid() {
+            continue
+        }
+
+        ok, err := matches(got, op, want)
+        if err != nil {
+            return nil, fmt.Errorf("where: %v", err)
+        }
+        if ok {
+            out = reflect.Append(out, item)
+        }
+    }
+    return out.Interface(), nil
+}
+
+func matches(got reflect.Value, op string, want reflect.Value) (bool, error) {
+    if op == "in" {
+        // El campo es una lista (ej: Tags) y contiene el valor
+        list, err := toSlice(got.Interface())
+        if err != nil {
+            return false, err
+        }
+        for i := 0; i < list.Len(); i++ {
+            if c, ok := compareValues(list.Index(i), want); ok && c == 0 {
+                return true, nil
+            }
+        }
+        return false, nil
+    }
+
+    c, ok := compareValues(got, want)
+    if !ok {
+        if op == "==" || op == "!=" {
+            igual := reflect.DeepEqual(got.Interface(), want.Interface())
+            return igual == (op == "=="), nil
+        }
+        return false, nil
+    }
+
+    switch op {
+    case "==", "eq":
+        return c == 0, nil
+    case "!=", "ne":
+        return c != 0, nil
+    case ">", "gt":
+        return c > 0, nil
+    case ">=", "ge":
+        return c >= 0, nil
+    case "<", "lt":
+        return c < 0, nil
+    case "<=", "le":
+        return c <= 0, nil
+    }
+    return false, fmt.Errorf("operador desconocido %q", op)
+}
+
+// sortBy coll "Campo" ["asc"|"desc"]
+func sortBy(coll any, key string, order ...string) (any, error) {
+    items, err := toSlice(coll)
+    if err != nil {
+        return nil, fmt.Errorf("sortBy: %v", err)
+    }
+
+    desc := len(order) > 0 && strings.EqualFold(order[0], "desc")
+
+    out := reflect.MakeSlice(reflect.SliceOf(items.Type().Elem()), items.Len(), items.Len())
+    reflect.Copy(out, items)
+
+    keys := make([]reflect.Value, out.Len())
+    for i := range keys {
+        if keys[i], err = fieldValue(out.Index(i), key); err != nil {
+            return nil, fmt.Errorf("sortBy: %v", err)
+        }
+    }
+
+    idx := make([]int, out.Len())
+    for i := range idx {
+        idx[i] = i
+    }
+    sort.SliceStable(idx, func(i, j int) bool {
+        // Los elementos sin el campo (ej: filas de data sin esa clave) van al final
+        fa, fb := isMissing(keys[idx[i]]), isMissing(keys[idx[j]])
+        if fa || fb {
+            return !fa && fb
+        }
+        c, _ := compareValues(keys[idx[i]], keys[idx[j]])
+        if desc {
+            return c > 0
+        }
+        return c < 0
+    })
+
+    sorted := reflect.MakeSlice(out.Type(), 0, out.Len())
+    for _, i := range idx {
+        sorted = reflect.Append(sorted, out.Index(i))
+    }
+    return sorted.Interface(), nil
+}
+
+type Group struct {
+    Key   any
+    Items []any
+}
+
+// groupBy coll "Campo" -> grupos en el orden en que aparece cada valor
+func groupBy(coll any, key string) ([]Group, error) {
+    items, err := toSlice(coll)
+    if err != nil {
+        return nil, fmt.Errorf("groupBy: %v", err)
+    }
+
+    var groups []Group
+    pos := make(map[string]int)
+    for i := 0; i < items.Len(); i++ {
+        item := items.Index(i)
+        v, err := fieldValue(item, key)
+        if err != nil {
+            return nil, fmt.Errorf("groupBy: %v", err)
+        }
+
+        var k any
+        if v.IsValid() {
+            k = v.Interface()
+        }
+        id := fmt.Sprint(k)
+
+        n, ok := pos[id]
+        if !ok {
+            n = len(groups)
+            pos[id] = n
+            groups = append(groups, Group{Key: k})
+        }
+        groups[n].Items = append(groups[n].Items, item.Interface())
+    }
+    return groups, nil
+}
+
+func first(n int, coll any) (any, error) {
+    items, err := toSlice(coll)
+    if err != nil {
+        return nil, fmt.Errorf("first: %v", err)
+    }
+    return items.Slice(0, min(max(n, 0), items.Len())).Interface(), nil
+}
+
+func last(n int, coll any) (any, error) {
+    items, err := toSlice(coll)
+    if err != nil {
+        return nil, fmt.Errorf("last: %v", err)
+    }
+    return items.Slice(max(items.Len()-max(n, 0), 0), items.Len()).Interface(), nil
+}
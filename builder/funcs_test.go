@@ -0,0 +1,150 @@
+package builder
+
+import (
+    "reflect"
+    "testing"
+)
+
+func fecha(s string) Date {
+    d, err := ParseDate(s)
+    if err != nil {
+        panic(err)
+    }
+    return d
+}
+
+var postsDePrueba = []Post{
+    {Title: "Uno", Date: fecha("2024-05-01"), Tags: []string{"go", "ssg"}, Fijado: true},
+    {Title: "Dos", Date: fecha("2025-01-01"), Tags: []string{"css"}},
+    {Title: "Tres", Date: fecha("2025-06-15"), Tags: []string{"go"}},
+}
+
+func titulos(t *testing.T, v any) []string {
+    t.Helper()
+    var out []string
+    switch items := v.(type) {
+    case []Post:
+        for _, p := range items {
+            out = append(out, p.Title)
+        }
+    case []map[string]any:
+        for _, m := range items {
+            out = append(out, m["name"].(string))
+        }
+    default:
+        t.Fatalf("tipo inesperado %T", v)
+    }
+    return out
+}
+
+func TestWhere(t *testing.T) {
+    tests := []struct {
+        name string
+        key  string
+        args []any
+        want []string
+    }{
+        {"igual implícito", "Title", []any{"Dos"}, []string{"Dos"}},
+        {"igual", "Title", []any{"==", "Tres"}, []string{"Tres"}},
+        {"distinto", "Title", []any{"!=", "Tres"}, []string{"Uno", "Dos"}},
+        {"in", "Tags", []any{"in", "go"}, []string{"Uno", "Tres"}},
+        {"in sin resultados", "Tags", []any{"in", "rust"}, nil},
+        {"bool", "Fijado", []any{true}, []string{"Uno"}},
+        {"fecha desde", "Date", []any{">=", "2025-01-01"}, []string{"Dos", "Tres"}},
+        {"fecha antes", "Date", []any{"<", "01-01-2025"}, []string{"Uno"}},
+        {"fecha como Date", "Date", []any{"==", fecha("2025-06-15")}, []string{"Tres"}},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := where(postsDePrueba, tt.key, tt.args...)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if g := titulos(t, got); !reflect.DeepEqual(g, tt.want) {
+                t.Errorf("where %s %v = %v, quería %v", tt.key, tt.args, g, tt.want)
+            }
+        })
+    }
+
+    if _, err := where(postsDePrueba, "Title", "~", "Uno"); err == nil {
+        t.Error("where con operador desconocido: quería un error")
+    }
+    if _, err := where(postsDePrueba, "NoExiste", "x"); err == nil {
+        t.Error("where con un campo inexistente: quería un error")
+    }
+}
+
+func TestSortBy(t *testing.T) {
+    // Filas de data/: no todas tienen las mismas claves
+    filas := []map[string]any{
+        {"name": "b", "orden": 2, "activo": true},
+        {"name": "sin-clave"},
+        {"name": "a", "orden": 1, "activo": false},
+        {"name": "c", "orden": 3, "activo": true},
+    }
+
+    tests := []struct {
+        name  string
+        coll  any
+        key   string
+        order []string
+        want  []string
+    }{
+        {"texto asc", postsDePrueba, "Title", nil, []string{"Dos", "Tres", "Uno"}},
+        {"texto desc", postsDePrueba, "Title", []string{"desc"}, []string{"Uno", "Tres", "Dos"}},
+        {"fecha desc", postsDePrueba, "Date", []string{"desc"}, []string{"Tres", "Dos", "Uno"}},
+        {"clave faltante asc", filas, "orden", []string{"asc"}, []string{"a", "b", "c", "sin-clave"}},
+        {"clave faltante desc", filas, "orden", []string{"desc"}, []string{"c", "b", "a", "sin-clave"}},
+        {"bool asc", filas, "activo", []string{"asc"}, []string{"a", "b", "c", "sin-clave"}},
+        {"bool desc", filas, "activo", []string{"desc"}, []string{"b", "c", "a", "sin-clave"}},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := sortBy(tt.coll, tt.key, tt.order...)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if g := titulos(t, got); !reflect.DeepEqual(g, tt.want) {
+                t.Errorf("sortBy %s %v = %v, quería %v", tt.key, tt.order, g, tt.want)
+            }
+        })
+    }
+}
+
+func TestFirstLast(t *testing.T) {
+    tests := []struct {
+        n     int
+        first []string
+        last  []string
+    }{
+        {0, nil, nil},
+        {-1, nil, nil},
+        {2, []string{"Uno", "Dos"}, []string{"Dos", "Tres"}},
+        {3, []string{"Uno", "Dos", "Tres"}, []string{"Uno", "Dos", "Tres"}},
+        {10, []string{"Uno", "Dos", "Tres"}, []string{"Uno", "Dos", "Tres"}},
+    }
+
+    for _, tt := range tests {
+        f, err := first(tt.n, postsDePrueba)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if g := titulos(t, f); !reflect.DeepEqual(g, tt.first) {
+            t.Errorf("first %d = %v, quería %v", tt.n, g, tt.first)
+        }
+
+        l, err := last(tt.n, postsDePrueba)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if g := titulos(t, l); !reflect.DeepEqual(g, tt.last) {
+            t.Errorf("last %d = %v, quería %v", tt.n, g, tt.last)
+        }
+    }
+
+    if _, err := first(1, "no es una lista"); err == nil {
+        t.Error("first sobre un texto: quería un error")
+    }
+}
@@ -0,0 +1,120 @@
+package builder
+
+import (
+    "bytes"
+    "fmt"
+    "html"
+    "os"
+    "regexp"
+    "strings"
+    "path/filepath"
+
+    "github.com/alecthomas/chroma/v2"
+    chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+    "github.com/alecthomas/chroma/v2/lexers"
+    "github.com/alecthomas/chroma/v2/styles"
+)
+
+// Archivo de estilos que genera "yamblg gen-style" y que importa style/index.css
+const syntaxCSS = "syntax.css"
+
+// Bloques de código con lenguaje: <pre><code class="language-go">...</code></pre>
+// (lo que genera goldmark para los fenced code y lo que se puede escribir a mano en HTML)
+var codeBlockRe = regexp.MustCompile(`(?s)<pre><code class="language-([\w+#.-]+)">(.*?)</code></pre>`)
+
+func syntaxFormatter(lineNumbers bool) *chromahtml.Formatter {
+    return chromahtml.New(
+        chromahtml.WithClasses(true),
+        chromahtml.WithLineNumbers(lineNumbers),
+    )
+}
+
+// Resalta los bloques de código del body con spans de clases (.chroma .k, .chroma .s, ...).
+// Los bloques sin lenguaje o con un lenguaje desconocido quedan como están.
+func highlightCode(body string, lineNumbers bool) (string, error) {
+    formatter := syntaxFormatter(lineNumbers)
+    style := styles.Fallback
+
+    var errHighlight error
+    resultado := codeBlockRe.ReplaceAllStringFunc(body, func(bloque string) string {
+        if errHighlight != nil {
+            return bloque
+        }
+
+        m := codeBlockRe.FindStringSubmatch(bloque)
+        lexer := lexers.Get(m[1])
+        if lexer == nil {
+            return bloque
+        }
+
+        iterator, err := chroma.Coalesce(lexer).Tokenise(nil, html.UnescapeString(m[2]))
+        if err != nil {
+            errHighlight = fmt.Errorf("lenguaje %s: %v", m[1], err)
+            return bloque
+        }
+
+        var buf bytes.Buffer
+        if err := formatter.Format(&buf, style, iterator); err != nil {
+            errHighlight = fmt.Errorf("lenguaje %s: %v", m[1], err)
+            return bloque
+        }
+        return buf.String()
+    })
+
+    return resultado, errHighlight
+}
+
+// Escribe en style/syntax.css los colores del tema para las clases de highlightCode
+// y se asegura de que style/index.css lo importe, así MinifyCSS lo incluye en el bundle.
+func GenerateStyle(theme string) (string, error) {
+    style, ok := styles.Registry[strings.ToLower(theme)]
+    if !ok {
+        return "", fmt.Errorf("tema desconocido %q (disponibles: %s)", theme, strings.Join(styles.Names(), ", "))
+    }
+
+    if _, err := os.Stat("style"); err != nil {
+        return "", err
+    }
+
+    var buf bytes.Buffer
+    fmt.Fprintf(&buf, "/* Generado por yamblg gen-style %s */\n", style.Name)
+    if err := syntaxFormatter(false).WriteCSS(&buf, style); err != nil {
+        return "", err
+    }
+
+    destino := filepath.Join("style", syntaxCSS)
+    if err := os.WriteFile(destino, buf.Bytes(), 0644); err != nil {
+        return "", err
+    }
+
+    return destino, importSyntaxCSS(filepath.Join("style", "index.css"))
+}
+
+// Agrega el @import de syntax.css al final de los imports de index.css si todavía no está
+func importSyntaxCSS(indexPath string) error {
+    data, err := os.ReadFile(indexPath)
+    if err != nil {
+        return err
+    }
+    content := string(data)
+    if strings.Contains(content, syntaxCSS) {
+        return nil
+    }
+
+    linea := fmt.Sprintf("@import url(\"%s\");\n", syntaxCSS)
+
+    // Los @import tienen que ir antes que cualquier otra regla
+    pos := 0
+    for _, l := range strings.SplitAfter(content, "\n") {
+        if !strings.HasPrefix(strings.TrimSpace(l), "@import") {
+            break
+        }
+        pos += len(l)
+    }
+    if pos > 0 && !strings.HasSuffix(content[:pos], "\n") {
+        linea = "\n" + linea
+    }
+
+    content = content[:pos] + linea + content[pos:]
+    return os.WriteFile(indexPath, []byte(content), 0644)
+}
@@ -0,0 +1,52 @@
+package builder
+
+import (
+    "bytes"
+    "fmt"
+    "strings"
+
+    "github.com/yuin/goldmark"
+    "github.com/yuin/goldmark/extension"
+    "github.com/yuin/goldmark/renderer/html"
+)
+
+const (
+    FormatHTML     = "html"
+    FormatMarkdown = "markdown"
+)
+
+// Conversor CommonMark + GFM (tablas, task lists, footnotes, etc.)
+// Se permite HTML crudo para que los posts viejos sigan funcionando.
+var md = goldmark.New(
+    goldmark.WithExtensions(
+        extension.GFM,
+        extension.Footnote,
+    ),
+    goldmark.WithRendererOptions(
+        html.WithUnsafe(),
+    ),
+)
+
+// Normaliza el valor de "format" (acepta "md" como alias)
+func normalizeFormat(format string) (string, error) {
+    switch strings.ToLower(strings.TrimSpace(format)) {
+    case "", FormatHTML:
+        return FormatHTML, nil
+    case FormatMarkdown, "md":
+        return FormatMarkdown, nil
+    }
+    return "", fmt.Errorf("formato desconocido %q (usar markdown o html)", format)
+}
+
+// Devuelve el body listo para inyectar en el template
+func renderBody(body string, format string) (string, error) {
+    if format != FormatMarkdown {
+        return body, nil
+    }
+
+    var buf bytes.Buffer
+    if err := md.Convert([]byte(body), &buf); err != nil {
+        return "", err
+    }
+    return buf.String(), nil
+}
@@ -0,0 +1,55 @@
+package builder
+
+import (
+    "fmt"
+    "log"
+
+    "github.com/spf13/afero"
+)
+
+// Los YAML de content/pages/ son páginas sueltas ("Sobre mí", "Contacto"):
+// se generan en /<slug>/ con pages/page.html y no aparecen en listados ni en el feed.
+const pagesSection = "pages"
+
+const pagePermalink = "/:slug/"
+
+// Separa las páginas sueltas de los posts
+func SplitPages(all []Post) ([]Post, []Post) {
+    var posts, pages []Post
+    for _, p := range all {
+        if p.IsPage {
+            pages = append(pages, p)
+        } else {
+            posts = append(posts, p)
+        }
+    }
+    return posts, pages
+}
+
+func (b *Builder) BuildStandalonePages(fs afero.Fs) {
+    pages := b.site.Pages
+    if _, ok := b.pages["page.html"]; !ok {
+        if len(pages) > 0 {
+            log.Printf("⚠️ Hay %d páginas en content/pages/ pero no existe pages/page.html", len(pages))
+        }
+        return
+    }
+
+    for i := range pages {
+        page := &pages[i]
+
+        result, err := b.render("page.html", b.data(b.site.postPage(KindStandalone, page)))
+        if err != nil {
+            log.Printf("Error en %s: %v", page.File, err)
+            continue
+        }
+
+        if err := CreateRoute(fs, RoutePermalink, page.Link, result); err != nil {
+            log.Fatal(err)
+        }
+        if err := copyBundle(fs, *page); err != nil {
+            log.Printf("Error copiando archivos de %s: %v", page.File, err)
+        }
+        fmt.Printf("✓ Página generada: %s\n", page.Link)
+    }
+}
@@ -0,0 +1,96 @@
+package builder
+
+import (
+    "slices"
+    "strconv"
+)
+
+// Datos de paginación disponibles en los templates como .Paginator.
+// Las URLs son relativas a BaseURL: {{ $.BaseURL }}{{ .Paginator.Next }}
+type Paginator struct {
+    PageNumber int
+    TotalPages int
+    TotalPosts int
+    PageSize   int
+    Posts      []Post
+    First      string
+    Last       string
+    Prev       string
+    Next       string
+    HasPrev    bool
+    HasNext    bool
+}
+
+// URL de la página n de un listado ("home" vive en la raíz)
+func pageURL(folderName string, n int) string {
+    base := folderName + "/"
+    if folderName == "home" || folderName == "index" {
+        base = ""
+    }
+    if n <= 1 {
+        return base
+    }
+    return base + "page/" + strconv.Itoa(n) + "/"
+}
+
+// Indica si la página de pages/ se pagina según config.yaml
+func (cfg Config) isPaginated(folderName string) bool {
+    return cfg.Pagination.PageSize > 0 && slices.Contains(cfg.Pagination.Pages, folderName)
+}
+
+// Páginas de un template de pages/: una sola (nil) si no se pagina.
+// Se paginan los posts que muestra la página: con usePinned activo la home solo lista los fijados.
+func (b *Builder) paginate(folderName string) []*Paginator {
+    cfg := b.site.Config
+    if !cfg.isPaginated(folderName) {
+        return []*Paginator{nil}
+    }
+
+    posts := b.site.Posts
+    if cfg.UsePinned.Active && (folderName == "home" || folderName == "index") {
+        posts = nil
+        for _, p := range b.site.Posts {
+            if p.Fijado {
+                posts = append(posts, p)
+            }
+        }
+    }
+    return Paginate(posts, cfg.Pagination.PageSize, folderName)
+}
+
+// Divide los posts en páginas de tamaño size. Siempre devuelve al menos una página.
+func Paginate(posts []Post, size int, folderName string) []*Paginator {
+    total := 1
+    if size > 0 && len(posts) > 0 {
+        total = (len(posts) + size - 1) / size
+    }
+
+    paginas := make([]*Paginator, 0, total)
+    for n := 1; n <= total; n++ {
+        desde, hasta := 0, len(posts)
+        if size > 0 {
+            desde = min((n-1)*size, len(posts))
+            hasta = min(n*size, len(posts))
+        }
+
+        p := &Paginator{
+            PageNumber: n,
+            TotalPages: total,
+            TotalPosts: len(posts),
+            PageSize:   size,
+            Posts:      posts[desde:hasta],
+            First:      pageURL(folderName, 1),
+            Last:       pageURL(folderName, total),
+            HasPrev:    n > 1,
+            HasNext:    n < total,
+        }
+        if p.HasPrev {
+            p.Prev = pageURL(folderName, n-1)
+        }
+        if p.HasNext {
+            p.Next = pageURL(folderName, n+1)
+        }
+        paginas = append(paginas, p)
+    }
+    return paginas
+}
@@ -3,6 +3,8 @@ package builder
 import (
     "fmt"
     "os"
+    "time"
+    "io/fs"
 	"strings"
     "html/template"
     "path/filepath"
@@ -12,53 +14,209 @@ import (
 
 type Post struct {	
 	Title       string `yaml:"title"`
-	Date        string `yaml:"date"`
+	Slug        string `yaml:"slug"`
+	Aliases     []string `yaml:"aliases"`
+	Date        Date   `yaml:"date"`
+	Updated     Date   `yaml:"updated"`
+	Weight      int    `yaml:"weight"`
 	Author      string `yaml:"author"`
     Email       string `yaml:"email"`
 	Body        string `yaml:"body"`
+	Format      string `yaml:"format"`
 	Description string `yaml:"description"`
+	Summary     string `yaml:"summary"`
+	WordCount   int    `yaml:"-"`
+	ReadingTime int    `yaml:"-"`
+	TableOfContents []*TocEntry `yaml:"-"`
+	// Enlaces a otros posts: fuera del JSON (jsonify) para no formar ciclos
+	Related     []*Post `yaml:"-" json:"-"`
+	Prev        *Post  `yaml:"-" json:"-"`
+	Next        *Post  `yaml:"-" json:"-"`
 	Fijado      bool   `yaml:"fijado"`
+	Tags        []string `yaml:"tags"`
+	Categories  []string `yaml:"categories"`
+	SeriesName  string `yaml:"series"`
+	SeriesOrder int    `yaml:"seriesOrder"`
+	Series      *PostSeries `yaml:"-" json:"-"`
+	Draft       bool   `yaml:"draft"`
+	IsFuture    bool   `yaml:"-"`
+	File        string `yaml:"-"`
+	Section     string `yaml:"-"`
+	IsPage      bool   `yaml:"-"`
+	BundleDir   string `yaml:"-"`
+	Resources   []string `yaml:"-"`
 	Link        string
+	LegacyLink  string `yaml:"-"`
     FullLink    string
     UrlUser     string
 }
 
-func LoadPosts() ([]Post, error) {
+// Carga todos los YAML de content/, incluidas las subcarpetas.
+// La carpeta de primer nivel (content/notas/...) es la sección del post.
+func LoadPosts(cfg Config, shortcodes *Shortcodes) ([]Post, error) {
 
 	directoryPath := "content"
 
-    files, err := os.ReadDir(directoryPath)
-    if err != nil {
+    if _, err := os.Stat(directoryPath); err != nil {
         return nil, err
     }
 
+    defaultFormat, err := normalizeFormat(cfg.DefaultFormat)
+    if err != nil {
+        return nil, fmt.Errorf("config.yaml: %v", err)
+    }
+
     var posts []Post
 
-    for _, file := range files {
-        if filepath.Ext(file.Name()) == ".yaml" || filepath.Ext(file.Name()) == ".yml" {
-            path := filepath.Join(directoryPath, file.Name())
-            
-            content, err := os.ReadFile(path)
-            if err != nil {
-                continue 
+    err = filepath.WalkDir(directoryPath, func(path string, d fs.DirEntry, err error) error {
+        if err != nil {
+            return err
+        }
+
+        // Page bundle: la carpeta es el post y el resto de sus archivos son recursos
+        if d.IsDir() {
+            index := bundleIndex(path)
+            if index == "" || path == directoryPath {
+                return nil
             }
 
-            var post Post
-            if err := yaml.Unmarshal(content, &post); err != nil {
-                return nil, fmt.Errorf("error parseando %s: %v", file.Name(), err)
+            post, err := loadPost(index, cfg, defaultFormat, shortcodes)
+            if err != nil {
+                return err
+            }
+            if post.Title == strings.TrimSuffix(filepath.Base(index), filepath.Ext(index)) {
+                post.Title = filepath.Base(path)
             }
-            
-			if post.Title == "" {
-                post.Title = strings.TrimSuffix(file.Name(), ".yaml")
+            post.BundleDir = path
+            post.Resources, err = bundleResources(path, index)
+            if err != nil {
+                return err
             }
+            post.Section = sectionOf(directoryPath, path)
+            post.IsPage = post.Section == pagesSection
 
             posts = append(posts, post)
+            return filepath.SkipDir
+        }
+
+        if filepath.Ext(path) != ".yaml" && filepath.Ext(path) != ".yml" {
+            return nil
+        }
+
+        post, err := loadPost(path, cfg, defaultFormat, shortcodes)
+        if err != nil {
+            return err
+        }
+        post.Section = sectionOf(directoryPath, filepath.Dir(path))
+        post.IsPage = post.Section == pagesSection
+
+        posts = append(posts, post)
+        return nil
+    })
+
+    return posts, err
+}
+
+// Sección del post: la carpeta de primer nivel que contiene a dir (vacía si está en content/)
+func sectionOf(contentDir string, dir string) string {
+    rel, err := filepath.Rel(contentDir, dir)
+    if err != nil || rel == "." {
+        return ""
+    }
+    partes := strings.Split(filepath.ToSlash(rel), "/")
+    // content/mi-post/ es un bundle sin sección; content/notas/mi-post/ está en "notas"
+    if len(partes) == 1 && bundleIndex(dir) != "" {
+        return ""
+    }
+    return slugify(partes[0])
+}
+
+func loadPost(path string, cfg Config, defaultFormat string, shortcodes *Shortcodes) (Post, error) {
+    var post Post
+
+    content, err := os.ReadFile(path)
+    if err != nil {
+        return post, err
+    }
+
+    if err := yaml.Unmarshal(content, &post); err != nil {
+        return post, fmt.Errorf("error parseando %s: %v", path, err)
+    }
+
+    post.File = path
+
+    if post.Title == "" {
+        post.Title = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+    }
+
+    // Si el post no indica formato, se usa el de config.yaml
+    if post.Format == "" {
+        post.Format = defaultFormat
+    }
+    post.Format, err = normalizeFormat(post.Format)
+    if err != nil {
+        return post, fmt.Errorf("error en %s: %v", path, err)
+    }
+
+    // Markdown/HTML con los shortcodes ({{< figure ... >}}) ya expandidos
+    post.Body, err = shortcodes.render(post.Body, post.Format, &post)
+    if err != nil {
+        return post, fmt.Errorf("error renderizando %s: %v", path, err)
+    }
+
+    // Resaltado de sintaxis en build (sin JavaScript en el cliente)
+    if cfg.Highlight.Active {
+        post.Body, err = highlightCode(post.Body, cfg.Highlight.LineNumbers)
+        if err != nil {
+            return post, fmt.Errorf("error resaltando código en %s: %v", path, err)
         }
     }
 
-    return posts, nil
+    // Anclas en h2-h4 e índice del post
+    post.Body, post.TableOfContents = buildTableOfContents(post.Body)
+    post.WordCount = wordCount(post.Body)
+    post.ReadingTime = readingTime(post.Body)
+
+    // Resumen automático; si no hay description se usa el resumen
+    if post.Summary == "" {
+        post.Summary = summarize(post.Body, cfg.SummaryLength)
+    }
+    if post.Description == "" {
+        post.Description = post.Summary
+    }
+
+    post.Date = post.Date.WithLocale(cfg.Locale)
+    post.Updated = post.Updated.WithLocale(cfg.Locale)
+
+    // Fecha posterior a hoy = publicación programada
+    post.IsFuture = post.Date.After(time.Now())
+
+    return post, nil
 }
 
 func (p Post) ContentBody() template.HTML {
     return template.HTML(p.Body)
-}
\ No newline at end of file
+}
+
+// Última modificación: updated si existe, si no la fecha de publicación
+func (p Post) LastMod() time.Time {
+    if p.Updated.IsZero() {
+        return p.Date.Time
+    }
+    return p.Updated.Time
+}
+
+// Quita borradores y posts programados según las opciones de build
+func FilterPosts(posts []Post, opts BuildOptions) []Post {
+    var visibles []Post
+    for _, p := range posts {
+        if p.Draft && !opts.Drafts {
+            continue
+        }
+        if p.IsFuture && !opts.Future {
+            continue
+        }
+        visibles = append(visibles, p)
+    }
+    return visibles
+}
@@ -0,0 +1,111 @@
+package builder
+
+import (
+    "fmt"
+    "strings"
+)
+
+// Patrón por defecto, igual a las URLs de siempre: /post/<titulo>/
+const defaultPermalink = "/post/:slug/"
+
+// Resuelve los tokens del patrón de config.yaml para un post.
+// Tokens: :year, :month, :day, :slug (slug o título), :title (siempre el título), :section
+func expandPermalink(pattern string, p Post) (string, error) {
+    return expandPermalinkWith(pattern, p, slugify)
+}
+
+func expandPermalinkWith(pattern string, p Post, slugFn func(string) string) (string, error) {
+    usaFecha := strings.Contains(pattern, ":year") || strings.Contains(pattern, ":month") || strings.Contains(pattern, ":day")
+    if usaFecha && p.Date.IsZero() {
+        return "", fmt.Errorf("el permalink %q usa la fecha pero el post no tiene date", pattern)
+    }
+
+    // El slug va directo a la ruta de public/: nada de carpetas ni "..".
+    if strings.ContainsAny(p.Slug, `/\`) || strings.Contains(p.Slug, "..") {
+        return "", fmt.Errorf("slug %q inválido (no puede tener /, \\ ni ..)", p.Slug)
+    }
+
+    slug := p.Slug
+    if slug == "" {
+        slug = slugFn(p.Title)
+    }
+    if slug == "" {
+        return "", fmt.Errorf("no se pudo generar un slug para %q (agregar slug:)", p.Title)
+    }
+
+    r := strings.NewReplacer(
+        ":year", fmt.Sprintf("%04d", p.Date.Year()),
+        ":month", fmt.Sprintf("%02d", p.Date.Month()),
+        ":day", fmt.Sprintf("%02d", p.Date.Day()),
+        ":slug", slug,
+        ":title", slugFn(p.Title),
+        ":section", p.Section,
+    )
+    link := strings.Trim(r.Replace(pattern), "/")
+    if link == "" {
+        return "", fmt.Errorf("el permalink %q genera una ruta vacía", pattern)
+    }
+    if !insidePublic(link) {
+        return "", fmt.Errorf("el permalink %q genera la ruta %q, fuera de public/", pattern, link)
+    }
+    return link + "/", nil
+}
+
+// Indica si una ruta relativa a public/ ("2026/01/mi-post") queda dentro de public/
+func insidePublic(ruta string) bool {
+    if strings.HasPrefix(ruta, "/") || strings.Contains(ruta, `\`) {
+        return false
+    }
+    for _, parte := range strings.Split(ruta, "/") {
+        if parte == ".." {
+            return false
+        }
+    }
+    return true
+}
+
+// Calcula Link y FullLink de cada post y corta el build si dos posts
+// terminan en la misma ruta de public/.
+func AssignPermalinks(posts []Post, cfg Config) error {
+    usados := make(map[string]string)
+    for i := range posts {
+        post := &posts[i]
+
+        link, err := expandPermalink(cfg.permalinkFor(*post), *post)
+        if err != nil {
+            return fmt.Errorf("%s: %v", post.File, err)
+        }
+
+        if otro, ok := usados[link]; ok {
+            return fmt.Errorf("%s y %s generan la misma ruta /%s (usar slug: para diferenciarlos)", otro, post.File, link)
+        }
+        usados[link] = post.File
+
+        post.Link = link
+        post.FullLink = strings.TrimSuffix(cfg.UserUrl+cfg.BaseURL, "/") + "/" + link
+    }
+
+    // Migración de slugs: ruta que generaba el slugify anterior (solo ASCII).
+    // Solo para posts: las páginas sueltas no existían con el slugify anterior.
+    if cfg.LegacySlugRedirects {
+        legacyUsados := make(map[string]bool)
+        for i := range posts {
+            post := &posts[i]
+            if post.IsPage {
+                continue
+            }
+            legacy, err := expandPermalinkWith(cfg.permalinkFor(*post), *post, legacySlugify)
+            if err != nil || legacy == post.Link {
+                continue
+            }
+            // Nunca pisar la ruta real de otro post; si dos posts tenían la misma
+            // ruta vieja, solo el primero se queda con la redirección
+            if _, ok := usados[legacy]; ok || legacyUsados[legacy] {
+                continue
+            }
+            legacyUsados[legacy] = true
+            post.LegacyLink = legacy
+        }
+    }
+    return nil
+}
@@ -0,0 +1,60 @@
+package builder
+
+import (
+    "fmt"
+    "sort"
+)
+
+// Valores de prevNext.within en config.yaml
+const (
+    withinAll     = ""
+    withinSection = "section"
+    withinTag     = "tag"
+)
+
+// Enlaza cada post con el anterior (.Prev, más viejo) y el siguiente (.Next, más nuevo) por fecha.
+// Con prevNext.within se limita a los posts de la misma sección o con algún tag en común.
+func PrevNext(posts []Post, cfg Config) error {
+    within := cfg.PrevNext.Within
+    if within != withinAll && within != withinSection && within != withinTag {
+        return fmt.Errorf("prevNext.within desconocido %q (usar section o tag)", within)
+    }
+
+    // Orden cronológico, independiente de sortPosts
+    orden := make([]int, len(posts))
+    for i := range orden {
+        orden[i] = i
+    }
+    sort.SliceStable(orden, func(a, b int) bool {
+        return posts[orden[a]].Date.Before(posts[orden[b]].Date.Time)
+    })
+
+    for k, i := range orden {
+        post := &posts[i]
+        post.Prev, post.Next = nil, nil
+
+        for j := k - 1; j >= 0; j-- {
+            if samePrevNextGroup(*post, posts[orden[j]], within) {
+                post.Prev = &posts[orden[j]]
+                break
+            }
+        }
+        for j := k + 1; j < len(orden); j++ {
+            if samePrevNextGroup(*post, posts[orden[j]], within) {
+                post.Next = &posts[orden[j]]
+                break
+            }
+        }
+    }
+    return nil
+}
+
+func samePrevNextGroup(a, b Post, within string) bool {
+    switch within {
+    case withinSection:
+        return a.Section == b.Section
+    case withinTag:
+        return jaccard(a.Tags, b.Tags) > 0
+    }
+    return true
+}
@@ -0,0 +1,240 @@
+package builder
+
+import (
+    "fmt"
+    "html"
+    "log"
+    "sort"
+    "strings"
+
+    "github.com/spf13/afero"
+)
+
+// Redirección de una ruta vieja del sitio a una nueva.
+// From y To son relativas a BaseURL ("post/viejo/"); To también puede ser una URL absoluta.
+type Redirect struct {
+    From   string
+    To     string
+    Source string // Archivo que la define (para los mensajes de error)
+    Legacy bool   // Slug viejo (legacySlugRedirects): si choca se descarta sin error
+}
+
+func isAbsoluteURL(s string) bool {
+    return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
+// Normaliza una ruta del sitio: sin BaseURL adelante y con "/" al final
+func normalizeSitePath(path string, baseURL string) string {
+    path = strings.TrimSpace(path)
+    if base := strings.Trim(baseURL, "/"); base != "" {
+        path = strings.TrimPrefix(strings.TrimPrefix(path, "/"), base+"/")
+    }
+    path = strings.Trim(path, "/")
+    if path == "" {
+        return ""
+    }
+    return path + "/"
+}
+
+// URL de destino para el navegador y URL canónica
+func (r Redirect) targets(cfg Config) (string, string) {
+    if isAbsoluteURL(r.To) {
+        return r.To, r.To
+    }
+    return cfg.BaseURL + r.To, strings.TrimSuffix(cfg.UserUrl+cfg.BaseURL, "/") + "/" + r.To
+}
+
+// Página mínima que redirige a target (meta refresh + canonical)
+func redirectHTML(target string, canonical string) []byte {
+    t := html.EscapeString(target)
+    c := html.EscapeString(canonical)
+    return []byte(fmt.Sprintf(`<!DOCTYPE html>
+<html lang="es">
+<head>
+<meta charset="UTF-8">
+<title>Redirigiendo…</title>
+<meta name="robots" content="noindex">
+<link rel="canonical" href="%s">
+<meta http-equiv="refresh" content="0; url=%s">
+</head>
+<body>
+<p>Esta página se movió a <a href="%s">%s</a>.</p>
+</body>
+</html>
+`, c, t, t, t))
+}
+
+// Junta las redirecciones de config.yaml (redirects), de cada post (aliases)
+// y, si está activo, las de los slugs viejos. Falla si una redirección pisa una
+// ruta generada del sitio (ver siteRoutes) o si dos apuntan desde la misma ruta.
+func CollectRedirects(cfg Config, posts []Post, routes map[string]string) ([]Redirect, error) {
+    var redirects []Redirect
+
+    froms := make([]string, 0, len(cfg.Redirects))
+    for from := range cfg.Redirects {
+        froms = append(froms, from)
+    }
+    sort.Strings(froms)
+    for _, from := range froms {
+        to := cfg.Redirects[from]
+        if !isAbsoluteURL(to) {
+            to = normalizeSitePath(to, cfg.BaseURL)
+        }
+        redirects = append(redirects, Redirect{From: normalizeSitePath(from, cfg.BaseURL), To: to, Source: "config.yaml"})
+    }
+
+    for _, p := range posts {
+        for _, alias := range p.Aliases {
+            redirects = append(redirects, Redirect{From: normalizeSitePath(alias, cfg.BaseURL), To: p.Link, Source: p.File})
+        }
+        if p.LegacyLink != "" {
+            redirects = append(redirects, Redirect{From: p.LegacyLink, To: p.Link, Source: p.File, Legacy: true})
+        }
+    }
+
+    ocupadas := make(map[string]string, len(routes))
+    for link, origen := range routes {
+        ocupadas[link] = origen
+    }
+
+    var validas []Redirect
+    for _, r := range redirects {
+        // From termina siendo una carpeta de public/: ni URLs absolutas ni ".."
+        if strings.Contains(r.From, "://") || !insidePublic(strings.TrimSuffix(r.From, "/")) {
+            return nil, fmt.Errorf("%s: la redirección %q no es una ruta del sitio (no puede tener .. ni ser una URL absoluta)", r.Source, r.From)
+        }
+        if r.From == "" {
+            return nil, fmt.Errorf("%s: no se puede redirigir la raíz del sitio", r.Source)
+        }
+        if r.From == r.To {
+            continue
+        }
+        if otro, ok := ocupadas[r.From]; ok {
+            if r.Legacy {
+                continue
+            }
+            return nil, fmt.Errorf("%s: la redirección /%s pisa la ruta de %s", r.Source, r.From, otro)
+        }
+        ocupadas[r.From] = r.Source
+        validas = append(validas, r)
+    }
+
+    return validas, nil
+}
+
+// Todas las rutas que genera el build (relativas a BaseURL, con "/" al final)
+// y el archivo o template que las genera. Falla si dos generan la misma ruta
+// (ej: content/pages/tags.yaml y el índice de tags), porque una pisaría a la otra.
+func (b *Builder) siteRoutes(paginasDetectadas []string) (map[string]string, error) {
+    routes := make(map[string]string)
+    var errRuta error
+    add := func(link string, origen string) {
+        if link = normalizeSitePath(link, ""); link == "" {
+            return
+        }
+        if otro, ok := routes[link]; ok && otro != origen && errRuta == nil {
+            errRuta = fmt.Errorf("%s y %s generan la misma ruta /%s", otro, origen, link)
+        }
+        routes[link] = origen
+    }
+
+    for _, p := range b.site.Posts {
+        add(p.Link, p.File)
+    }
+    for _, p := range b.site.Pages {
+        add(p.Link, p.File)
+    }
+
+    if _, ok := b.pages["taxonomy.html"]; ok {
+        for _, name := range taxonomyNames {
+            tax := b.site.Taxonomies[name]
+            for _, term := range tax.Terms {
+                add(term.Link, "pages/taxonomy.html")
+            }
+            if _, ok := b.pages["terms.html"]; ok {
+                add(tax.Link, "pages/terms.html")
+            }
+        }
+    }
+
+    if _, ok := b.pages["section.html"]; ok {
+        for _, section := range b.site.Sections {
+            add(section.Link, "content/"+section.Name+"/")
+        }
+    }
+
+    if _, ok := b.pages["archive.html"]; ok {
+        for _, page := range archivePages(b.site.Archive) {
+            add("archive/"+page.slug, "pages/archive.html")
+        }
+    }
+
+    if _, ok := b.pages["series.html"]; ok {
+        for _, s := range b.site.Series {
+            add(s.Link, "pages/series.html")
+        }
+    }
+
+    for _, nombreArchivo := range paginasDetectadas {
+        if reservedPages[nombreArchivo] || isSectionTemplate(b.site.Sections, nombreArchivo) {
+            continue
+        }
+        folderName := strings.TrimSuffix(nombreArchivo, ".html")
+        for _, pag := range b.paginate(folderName) {
+            n := 1
+            if pag != nil {
+                n = pag.PageNumber
+            }
+            add(pageURL(folderName, n), "pages/"+nombreArchivo)
+        }
+    }
+
+    if _, ok := b.pages["404.html"]; ok {
+        add("404.html", "pages/404.html")
+    }
+
+    return routes, errRuta
+}
+
+// Escribe una página de redirección por cada ruta vieja y, si se pide en
+// config.yaml, los archivos _redirects (Netlify) y nginx-redirects.conf
+func WriteRedirects(fs afero.Fs, cfg Config, redirects []Redirect) {
+    for _, r := range redirects {
+        target, canonical := r.targets(cfg)
+        result := RenderResult{Content: redirectHTML(target, canonical)}
+        if err := CreateRoute(fs, RoutePermalink, r.From, result); err != nil {
+            log.Printf("Error redirigiendo %s: %v", r.From, err)
+            continue
+        }
+        fmt.Printf("↪ Redirección: /%s -> %s\n", r.From, target)
+    }
+
+    if len(redirects) == 0 {
+        return
+    }
+
+    if cfg.RedirectFiles.Netlify {
+        var b strings.Builder
+        for _, r := range redirects {
+            target, _ := r.targets(cfg)
+            fmt.Fprintf(&b, "%s%s %s 301\n", cfg.BaseURL, r.From, target)
+        }
+        if err := afero.WriteFile(fs, "public/_redirects", []byte(b.String()), 0644); err != nil {
+            log.Printf("Error escribiendo _redirects: %v", err)
+        }
+    }
+
+    if cfg.RedirectFiles.Nginx {
+        var b strings.Builder
+        b.WriteString("# Generado por yamblg. Uso: if ($yamblg_redirect) { return 301 $yamblg_redirect; }\n")
+        b.WriteString("map $uri $yamblg_redirect {\n")
+        for _, r := range redirects {
+            target, _ := r.targets(cfg)
+            fmt.Fprintf(&b, "    %s%s %s;\n", cfg.BaseURL, r.From, target)
+        }
+        b.WriteString("}\n")
+        if err := afero.WriteFile(fs, "public/nginx-redirects.conf", []byte(b.String()), 0644); err != nil {
+            log.Printf("Error escribiendo nginx-redirects.conf: %v", err)
+        }
+    }
+}
@@ -0,0 +1,62 @@
+package builder
+
+import (
+    "strings"
+    "testing"
+)
+
+func TestCollectRedirects(t *testing.T) {
+    routes := map[string]string{
+        "post/nuevo/": "content/nuevo.yaml",
+        "tags/go/":    "pages/taxonomy.html",
+    }
+
+    tests := []struct {
+        name      string
+        redirects map[string]string
+        aliases   []string
+        want      string // From de la única redirección esperada
+        err       string // parte del mensaje de error esperado
+    }{
+        {name: "alias", aliases: []string{"/post/viejo/"}, want: "post/viejo/"},
+        {name: "alias con baseURL", aliases: []string{"/Yamblg/post/viejo"}, want: "post/viejo/"},
+        {name: "config", redirects: map[string]string{"viejo": "post/nuevo/"}, want: "viejo/"},
+        {name: "alias igual al destino", aliases: []string{"post/nuevo/"}},
+        {name: "alias con ..", aliases: []string{"../pages/"}, err: "no es una ruta del sitio"},
+        {name: "alias con .. en el medio", aliases: []string{"/post/../../layout/"}, err: "no es una ruta del sitio"},
+        {name: "alias con barra invertida", aliases: []string{`..\pages`}, err: "no es una ruta del sitio"},
+        {name: "alias URL absoluta", aliases: []string{"https://otro.com/post/"}, err: "no es una ruta del sitio"},
+        {name: "config con ..", redirects: map[string]string{"../../etc/": "post/nuevo/"}, err: "no es una ruta del sitio"},
+        {name: "config URL absoluta", redirects: map[string]string{"http://otro.com/": "post/nuevo/"}, err: "no es una ruta del sitio"},
+        {name: "raíz", aliases: []string{"/"}, err: "raíz del sitio"},
+        {name: "pisa una ruta", aliases: []string{"tags/go/"}, err: "pisa la ruta de pages/taxonomy.html"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            cfg := Config{BaseURL: "/Yamblg/", Redirects: tt.redirects}
+            posts := []Post{{File: "content/nuevo.yaml", Link: "post/nuevo/", Aliases: tt.aliases}}
+
+            got, err := CollectRedirects(cfg, posts, routes)
+            if tt.err != "" {
+                if err == nil || !strings.Contains(err.Error(), tt.err) {
+                    t.Fatalf("error = %v, quería %q", err, tt.err)
+                }
+                return
+            }
+            if err != nil {
+                t.Fatal(err)
+            }
+
+            if tt.want == "" {
+                if len(got) != 0 {
+                    t.Fatalf("redirecciones = %+v, no quería ninguna", got)
+                }
+                return
+            }
+            if len(got) != 1 || got[0].From != tt.want || got[0].To != "post/nuevo/" {
+                t.Fatalf("redirecciones = %+v, quería /%s -> post/nuevo/", got, tt.want)
+            }
+        })
+    }
+}
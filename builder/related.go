@@ -0,0 +1,172 @@
+package builder
+
+import (
+    "math"
+    "sort"
+    "strings"
+)
+
+// Peso de cada criterio por defecto (related.weights en config.yaml)
+const (
+    defaultRelatedCount = 3
+    defaultWeightTags   = 1.0
+    defaultWeightTerms  = 1.0
+    defaultWeightDate   = 0.3
+)
+
+// Palabras demasiado comunes para relacionar posts
+var stopWords = map[string]bool{
+    "que": true, "con": true, "para": true, "por": true, "como": true, "una": true,
+    "los": true, "las": true, "del": true, "sin": true, "mas": true, "pero": true,
+    "sus": true, "este": true, "esta": true, "esto": true, "son": true, "hay": true,
+    "the": true, "and": true, "for": true, "with": true, "that": true, "this": true,
+}
+
+// Calcula .Related de cada post: los más parecidos por tags compartidos,
+// términos del título y el cuerpo (TF-IDF) y cercanía de fecha.
+func RelatedPosts(posts []Post, cfg Config) {
+    if !cfg.Related.Active {
+        return
+    }
+    count := cfg.Related.Count
+    if count <= 0 {
+        count = defaultRelatedCount
+    }
+
+    wTags, wTerms, wDate := cfg.relatedWeights()
+
+    vectores := tfidf(posts)
+
+    type candidato struct {
+        post  *Post
+        score float64
+    }
+
+    for i := range posts {
+        var candidatos []candidato
+        for j := range posts {
+            if i == j {
+                continue
+            }
+
+            tags := jaccard(posts[i].Tags, posts[j].Tags)
+            terms := cosine(vectores[i], vectores[j])
+            // La fecha solo desempata: sin tags ni términos en común no hay relación
+            if tags == 0 && terms == 0 {
+                continue
+            }
+
+            score := wTags*tags + wTerms*terms + wDate*dateProximity(posts[i], posts[j])
+            candidatos = append(candidatos, candidato{&posts[j], score})
+        }
+
+        sort.SliceStable(candidatos, func(a, b int) bool {
+            return candidatos[a].score > candidatos[b].score
+        })
+
+        posts[i].Related = nil
+        for k := 0; k < len(candidatos) && k < count; k++ {
+            posts[i].Related = append(posts[i].Related, candidatos[k].post)
+        }
+    }
+}
+
+// Pesos de config.yaml; si no se indica ninguno se usan los de por defecto
+func (cfg Config) relatedWeights() (float64, float64, float64) {
+    w := cfg.Related.Weights
+    if w.Tags == 0 && w.Terms == 0 && w.Date == 0 {
+        return defaultWeightTags, defaultWeightTerms, defaultWeightDate
+    }
+    return w.Tags, w.Terms, w.Date
+}
+
+// Palabras normalizadas (sin acentos ni mayúsculas) del título y el texto del post.
+// El título cuenta doble.
+func postTerms(p Post) []string {
+    texto := p.Title + " " + p.Title + " " + plainify(p.Body)
+    var terms []string
+    for _, t := range strings.Split(slugify(texto), "-") {
+        if len([]rune(t)) < 3 || stopWords[t] {
+            continue
+        }
+        terms = append(terms, t)
+    }
+    return terms
+}
+
+// Vector TF-IDF de cada post (normalizado, así el coseno es un producto escalar)
+func tfidf(posts []Post) []map[string]float64 {
+    frecuencias := make([]map[string]float64, len(posts))
+    documentos := map[string]int{}
+
+    for i, p := range posts {
+        tf := map[string]float64{}
+        for _, t := range postTerms(p) {
+            tf[t]++
+        }
+        for t := range tf {
+            documentos[t]++
+        }
+        frecuencias[i] = tf
+    }
+
+    total := float64(len(posts))
+    for _, tf := range frecuencias {
+        var norma float64
+        for t, f := range tf {
+            tf[t] = f * math.Log(1+total/float64(documentos[t]))
+            norma += tf[t] * tf[t]
+        }
+        norma = math.Sqrt(norma)
+        for t := range tf {
+            tf[t] /= norma
+        }
+    }
+    return frecuencias
+}
+
+func cosine(a, b map[string]float64) float64 {
+    if len(a) > len(b) {
+        a, b = b, a
+    }
+    var total float64
+    for t, v := range a {
+        total += v * b[t]
+    }
+    return total
+}
+
+// Proporción de tags en común (0 a 1)
+func jaccard(a, b []string) float64 {
+    if len(a) == 0 || len(b) == 0 {
+        return 0
+    }
+    set := map[string]bool{}
+    for _, t := range a {
+        set[slugify(t)] = true
+    }
+    comunes, union := 0, len(set)
+    vistos := map[string]bool{}
+    for _, t := range b {
+        s := slugify(t)
+        if vistos[s] {
+            continue
+        }
+        vistos[s] = true
+        if set[s] {
+            comunes++
+        } else {
+            union++
+        }
+    }
+    return float64(comunes) / float64(union)
+}
+
+// 1 para el mismo día, 0.5 a un año de distancia, tendiendo a 0
+func dateProximity(a, b Post) float64 {
+    if a.Date.IsZero() || b.Date.IsZero() {
+        return 0
+    }
+    dias := math.Abs(a.Date.Sub(b.Date.Time).Hours() / 24)
+    return 1 / (1 + dias/365)
+}
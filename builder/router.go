@@ -13,15 +13,38 @@ type RouteType int
 const (
     RoutePublic RouteType = iota
     RoutePost                   
+    RouteTaxonomy
+    RoutePaginated
+    RouteArchive
+    RoutePermalink
+    RouteFile
 )
 
 func CreateRoute(fs afero.Fs,routeType RouteType, slug string, result RenderResult) error {
     var baseDir string
 
+    // Archivo plano: public/404.html en vez de public/404/index.html
+    if routeType == RouteFile {
+        return afero.WriteFile(fs, filepath.Join("public", result.FolderName+".html"), result.Content, 0644)
+    }
+
     switch routeType {
     case RoutePost:
         // Une el folderName del template ("post") con el slug del post
         baseDir = filepath.Join("public", result.FolderName, slug)
+    case RoutePermalink:
+        // El slug es la ruta completa del permalink ("2026/01/mi-post/")
+        baseDir = filepath.Join("public", filepath.FromSlash(slug))
+    case RouteTaxonomy, RouteArchive:
+        // public/tags/ (índice), public/tags/<slug>/ o public/archive/2026/01/
+        baseDir = filepath.Join("public", result.FolderName, slug)
+    case RoutePaginated:
+        // Página n de un listado: public/page/<n>/ o public/<pagina>/page/<n>/
+        if result.FolderName == "home" || result.FolderName == "index" {
+            baseDir = filepath.Join("public", "page", slug)
+        } else {
+            baseDir = filepath.Join("public", result.FolderName, "page", slug)
+        }
     case RoutePublic:
         // Para páginas raíz, si es "home", lo mandamos directo a public/
         if result.FolderName == "home" || result.FolderName == "index" {
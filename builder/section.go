@@ -0,0 +1,79 @@
+package builder
+
+import (
+    "sort"
+)
+
+// Permalink por defecto de los posts dentro de una sección (content/<seccion>/...)
+const defaultSectionPermalink = "/:section/:slug/"
+
+type SectionConfig struct {
+    Title     string `yaml:"title"`
+    Permalink string `yaml:"permalink"`
+}
+
+type Section struct {
+    Name  string
+    Title string
+    Link  string
+    Posts []Post
+}
+
+// Agrupa los posts por sección, respetando el orden de allPosts
+func BuildSections(posts []Post, cfg Config) []*Section {
+    var sections []*Section
+    byName := make(map[string]*Section)
+
+    for _, p := range posts {
+        if p.Section == "" {
+            continue
+        }
+
+        s, ok := byName[p.Section]
+        if !ok {
+            s = &Section{
+                Name:  p.Section,
+                Title: cfg.Sections[p.Section].Title,
+                Link:  p.Section + "/",
+            }
+            if s.Title == "" {
+                s.Title = p.Section
+            }
+            byName[p.Section] = s
+            sections = append(sections, s)
+        }
+        s.Posts = append(s.Posts, p)
+    }
+
+    sort.Slice(sections, func(i, j int) bool {
+        return sections[i].Name < sections[j].Name
+    })
+    return sections
+}
+
+// Patrón de permalink para un post según su sección
+func (cfg Config) permalinkFor(p Post) string {
+    if p.IsPage {
+        return pagePermalink
+    }
+    if p.Section == "" {
+        if cfg.Permalink == "" {
+            return defaultPermalink
+        }
+        return cfg.Permalink
+    }
+    if sc := cfg.Sections[p.Section]; sc.Permalink != "" {
+        return sc.Permalink
+    }
+    return defaultSectionPermalink
+}
+
+// Template de un post: pages/<seccion>.html si existe, si no pages/post.html
+func (b *Builder) postTemplate(p Post) string {
+    if p.Section != "" {
+        if _, ok := b.pages[p.Section+".html"]; ok {
+            return p.Section + ".html"
+        }
+    }
+    return "post.html"
+}
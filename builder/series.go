@@ -0,0 +1,91 @@
+package builder
+
+import (
+    "sort"
+)
+
+// Carpeta de las páginas de cada serie: /series/<slug>/
+const seriesBase = "series"
+
+// Serie de posts (tutoriales en varias partes), en orden de lectura
+type Series struct {
+    Name  string
+    Slug  string
+    Link  string
+    Posts []*Post
+}
+
+// La serie vista desde uno de sus posts (.Post.Series)
+type PostSeries struct {
+    *Series
+    Index int   // Posición del post en Posts (desde 0)
+    Prev  *Post // Parte anterior
+    Next  *Post // Parte siguiente
+}
+
+// Número de parte del post (desde 1)
+func (s PostSeries) Number() int {
+    return s.Index + 1
+}
+
+func (s PostSeries) Total() int {
+    return len(s.Posts)
+}
+
+// Agrupa los posts con "series" y completa .Series en cada uno.
+// Las partes se ordenan por seriesOrder y, sin orden o empatadas, por fecha.
+func BuildSeries(posts []Post) []*Series {
+    var series []*Series
+    bySlug := make(map[string]*Series)
+
+    for i := range posts {
+        p := &posts[i]
+        p.Series = nil
+        if p.SeriesName == "" {
+            continue
+        }
+
+        slug := slugify(p.SeriesName)
+        s, ok := bySlug[slug]
+        if !ok {
+            s = &Series{
+                Name: p.SeriesName,
+                Slug: slug,
+                Link: seriesBase + "/" + slug + "/",
+            }
+            bySlug[slug] = s
+            series = append(series, s)
+        }
+        s.Posts = append(s.Posts, p)
+    }
+
+    for _, s := range series {
+        sort.SliceStable(s.Posts, func(i, j int) bool {
+            a, b := s.Posts[i], s.Posts[j]
+            if a.SeriesOrder != b.SeriesOrder {
+                // Los que no indican orden van después de los numerados
+                if a.SeriesOrder == 0 || b.SeriesOrder == 0 {
+                    return b.SeriesOrder == 0
+                }
+                return a.SeriesOrder < b.SeriesOrder
+            }
+            return a.Date.Before(b.Date.Time)
+        })
+
+        for i, p := range s.Posts {
+            ps := &PostSeries{Series: s, Index: i}
+            if i > 0 {
+                ps.Prev = s.Posts[i-1]
+            }
+            if i < len(s.Posts)-1 {
+                ps.Next = s.Posts[i+1]
+            }
+            p.Series = ps
+        }
+    }
+
+    sort.Slice(series, func(i, j int) bool {
+        return series[i].Slug < series[j].Slug
+    })
+    return series
+}
@@ -0,0 +1,328 @@
+package builder
+
+import (
+    "bytes"
+    "fmt"
+    "html/template"
+    "path/filepath"
+    "regexp"
+    "strconv"
+    "strings"
+)
+
+// Shortcodes: bloques reutilizables dentro del body de los posts.
+//
+//   {{< figure src="foto.jpg" caption="Texto" >}}
+//   {{< aviso tipo="nota" >}}Contenido del aviso{{< /aviso >}}
+//   {{< youtube dQw4w9WgXcQ >}}
+//
+// Cada uno es un template de shortcodes/<nombre>.html que recibe un Shortcode.
+// Dentro de bloques o spans de código no se expanden. Para mostrar la sintaxis
+// tal cual en cualquier lugar se escribe {{</* figure */>}}, que queda como {{< figure >}}.
+type Shortcodes struct {
+    tmpl  *template.Template
+    names map[string]bool
+}
+
+// Datos que recibe el template del shortcode
+type Shortcode struct {
+    Name   string
+    Params map[string]string // parámetros con nombre: src="foto.jpg"
+    Args   []string          // parámetros posicionales: {{< youtube id >}}
+    Inner  template.HTML     // contenido entre apertura y cierre, ya renderizado
+    Post   *Post
+}
+
+// Devuelve un parámetro por nombre ("src") o por posición (0)
+func (s Shortcode) Get(key any) string {
+    switch k := key.(type) {
+    case int:
+        if k >= 0 && k < len(s.Args) {
+            return s.Args[k]
+        }
+    case string:
+        return s.Params[k]
+    }
+    return ""
+}
+
+// Parsea shortcodes/*.html sobre un clon de la base, así pueden usar funciones y componentes
+func loadShortcodes(base *template.Template, dir string) (*Shortcodes, error) {
+    sc := &Shortcodes{names: make(map[string]bool)}
+
+    files, _ := filepath.Glob(filepath.Join(dir, "*.html"))
+    if len(files) == 0 {
+        return sc, nil
+    }
+
+    t, err := base.Clone()
+    if err != nil {
+        return nil, err
+    }
+    sc.tmpl, err = t.ParseFiles(files...)
+    if err != nil {
+        return nil, err
+    }
+
+    for _, f := range files {
+        sc.names[strings.TrimSuffix(filepath.Base(f), ".html")] = true
+    }
+    return sc, nil
+}
+
+// {{< nombre params >}} y {{< /nombre >}}
+var shortcodeRe = regexp.MustCompile(`\{\{<\s*(/?)\s*([\w-]+)((?:[^>]|>[^}])*?)\s*>\}\}`)
+
+// name="valor", name=valor, "valor" o valor
+var shortcodeParamRe = regexp.MustCompile(`([\w-]+)=("(?:[^"\\]|\\.)*"|\S+)|("(?:[^"\\]|\\.)*"|\S+)`)
+
+// {{</* ... */>}}: shortcode escapado, se muestra sin ejecutar
+var shortcodeEscapeRe = regexp.MustCompile(`(?s)\{\{<\s*/\*(.*?)\*/\s*>\}\}`)
+
+// Código inline en Markdown: `código` o ``código con ` ``
+var inlineCodeRe = regexp.MustCompile("``[^\n]*?``|`[^`\n]+`")
+
+// <pre>...</pre> y <code>...</code> en HTML (también válidos dentro de Markdown)
+var htmlCodeRe = regexp.MustCompile(`(?is)<pre\b.*?</pre>|<code\b.*?</code>`)
+
+type shortcodeTag struct {
+    start, end int
+    closing    bool
+    name       string
+    params     string
+}
+
+// Renderiza el body en su formato con los shortcodes expandidos.
+// Los shortcodes se reemplazan por marcas antes del Markdown y su HTML se pega después,
+// así goldmark no toca la salida de los templates.
+func (sc *Shortcodes) render(body string, format string, post *Post) (string, error) {
+    conMarcas, bloques, err := sc.extract(body, format, post)
+    if err != nil {
+        return "", err
+    }
+
+    html, err := renderBody(conMarcas, format)
+    if err != nil {
+        return "", err
+    }
+
+    for i, bloque := range bloques {
+        html = placeShortcode(html, shortcodeMark(i), bloque)
+    }
+    return html, nil
+}
+
+// Shortcodes que generan un bloque (<div>, <figure>, ...) y no pueden quedar dentro de un <p>
+var blockTagRe = regexp.MustCompile(`^<(?i:div|figure|aside|section|article|nav|header|footer|blockquote|details|pre|table|ul|ol|dl|p|h[1-6]|hr)\b`)
+
+// Pega el HTML del shortcode en lugar de su marca. Si es un bloque y quedó dentro de
+// un párrafo (solo en su línea o en medio del texto), el párrafo se parte:
+// <p>antes</p> bloque <p>después</p>, sin párrafos vacíos.
+func placeShortcode(html string, marca string, bloque string) string {
+    i := strings.Index(html, marca)
+    if i < 0 {
+        return html
+    }
+    fin := i + len(marca)
+
+    apertura := strings.LastIndex(html[:i], "<p>")
+    cierre := strings.Index(html[fin:], "</p>")
+    dentroDeP := apertura >= 0 && cierre >= 0 && !strings.Contains(html[apertura:i], "</p>")
+    if !blockTagRe.MatchString(bloque) || !dentroDeP {
+        return html[:i] + bloque + html[fin:]
+    }
+    cierre += fin
+
+    var out strings.Builder
+    out.WriteString(html[:apertura])
+    if antes := strings.TrimSpace(html[apertura+len("<p>") : i]); antes != "" {
+        out.WriteString("<p>" + antes + "</p>\n")
+    }
+    out.WriteString(bloque)
+    if despues := strings.TrimSpace(html[fin:cierre]); despues != "" {
+        out.WriteString("\n<p>" + despues + "</p>")
+    }
+    out.WriteString(html[cierre+len("</p>"):])
+    return out.String()
+}
+
+func shortcodeMark(i int) string {
+    return fmt.Sprintf("YAMBLGSHORTCODE%dEND", i)
+}
+
+// Reemplaza los shortcodes de primer nivel por marcas y devuelve el HTML de cada uno
+func (sc *Shortcodes) extract(body string, format string, post *Post) (string, []string, error) {
+    codigo := codeRanges(body, format)
+
+    var tags []shortcodeTag
+    for _, m := range shortcodeRe.FindAllStringSubmatchIndex(body, -1) {
+        if insideRanges(codigo, m[0]) {
+            continue
+        }
+        tags = append(tags, shortcodeTag{
+            start:   m[0],
+            end:     m[1],
+            closing: body[m[2]:m[3]] == "/",
+            name:    body[m[4]:m[5]],
+            params:  body[m[6]:m[7]],
+        })
+    }
+    if len(tags) == 0 {
+        return unescapeShortcodes(body), nil, nil
+    }
+
+    var out strings.Builder
+    var bloques []string
+    ultimo := 0
+
+    for i := 0; i < len(tags); i++ {
+        tag := tags[i]
+        if tag.closing {
+            return "", nil, fmt.Errorf("shortcode %q cerrado sin abrir", tag.name)
+        }
+
+        data := Shortcode{Name: tag.name, Post: post, Params: map[string]string{}}
+        if err := parseShortcodeParams(tag.params, &data); err != nil {
+            return "", nil, fmt.Errorf("shortcode %q: %v", tag.name, err)
+        }
+
+        fin := tag.end
+        if cierre := matchingClose(tags, i); cierre > 0 {
+            inner, err := sc.render(body[tag.end:tags[cierre].start], format, post)
+            if err != nil {
+                return "", nil, err
+            }
+            data.Inner = template.HTML(strings.TrimSpace(inner))
+            fin = tags[cierre].end
+            i = cierre
+        }
+
+        bloque, err := sc.execute(data)
+        if err != nil {
+            return "", nil, err
+        }
+
+        out.WriteString(unescapeShortcodes(body[ultimo:tag.start]))
+        out.WriteString(shortcodeMark(len(bloques)))
+        bloques = append(bloques, bloque)
+        ultimo = fin
+    }
+    out.WriteString(unescapeShortcodes(body[ultimo:]))
+
+    return out.String(), bloques, nil
+}
+
+func unescapeShortcodes(s string) string {
+    return shortcodeEscapeRe.ReplaceAllString(s, "{{<$1>}}")
+}
+
+// Rangos del body que son código y donde los shortcodes se dejan como están
+func codeRanges(body string, format string) [][2]int {
+    var rangos [][2]int
+    for _, m := range htmlCodeRe.FindAllStringIndex(body, -1) {
+        rangos = append(rangos, [2]int{m[0], m[1]})
+    }
+    if format != FormatMarkdown {
+        return rangos
+    }
+
+    rangos = append(rangos, fencedCodeRanges(body)...)
+    for _, m := range inlineCodeRe.FindAllStringIndex(body, -1) {
+        rangos = append(rangos, [2]int{m[0], m[1]})
+    }
+    return rangos
+}
+
+// Bloques ``` o ~~~ de Markdown (un bloque sin cerrar llega hasta el final)
+func fencedCodeRanges(body string) [][2]int {
+    var rangos [][2]int
+    var valla string
+    inicio, pos := 0, 0
+
+    for _, linea := range strings.SplitAfter(body, "\n") {
+        texto := strings.TrimSpace(linea)
+        switch {
+        case valla == "" && (strings.HasPrefix(texto, "```") || strings.HasPrefix(texto, "~~~")):
+            valla = texto[:3]
+            inicio = pos
+        case valla != "" && strings.HasPrefix(texto, valla) && strings.Trim(texto, valla[:1]) == "":
+            rangos = append(rangos, [2]int{inicio, pos + len(linea)})
+            valla = ""
+        }
+        pos += len(linea)
+    }
+    if valla != "" {
+        rangos = append(rangos, [2]int{inicio, len(body)})
+    }
+    return rangos
+}
+
+func insideRanges(rangos [][2]int, pos int) bool {
+    for _, r := range rangos {
+        if pos >= r[0] && pos < r[1] {
+            return true
+        }
+    }
+    return false
+}
+
+// Busca el cierre del shortcode tags[i] respetando anidados del mismo nombre (-1 si no tiene)
+func matchingClose(tags []shortcodeTag, i int) int {
+    nivel := 0
+    for j := i + 1; j < len(tags); j++ {
+        if tags[j].name != tags[i].name {
+            continue
+        }
+        if !tags[j].closing {
+            nivel++
+            continue
+        }
+        if nivel == 0 {
+            return j
+        }
+        nivel--
+    }
+    return -1
+}
+
+func parseShortcodeParams(params string, data *Shortcode) error {
+    for _, m := range shortcodeParamRe.FindAllStringSubmatch(params, -1) {
+        if m[1] != "" {
+            valor, err := unquoteParam(m[2])
+            if err != nil {
+                return err
+            }
+            data.Params[m[1]] = valor
+            continue
+        }
+        valor, err := unquoteParam(m[3])
+        if err != nil {
+            return err
+        }
+        data.Args = append(data.Args, valor)
+    }
+    return nil
+}
+
+func unquoteParam(valor string) (string, error) {
+    if !strings.HasPrefix(valor, `"`) {
+        return valor, nil
+    }
+    s, err := strconv.Unquote(valor)
+    if err != nil {
+        return "", fmt.Errorf("parámetro mal escrito %s", valor)
+    }
+    return s, nil
+}
+
+func (sc *Shortcodes) execute(data Shortcode) (string, error) {
+    if sc == nil || !sc.names[data.Name] {
+        return "", fmt.Errorf("shortcode desconocido %q (falta shortcodes/%s.html)", data.Name, data.Name)
+    }
+
+    var buf bytes.Buffer
+    if err := sc.tmpl.ExecuteTemplate(&buf, data.Name+".html", data); err != nil {
+        return "", fmt.Errorf("shortcode %q: %v", data.Name, err)
+    }
+    return strings.TrimSpace(buf.String()), nil
+}
@@ -0,0 +1,98 @@
+package builder
+
+import (
+    "html/template"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+)
+
+func testShortcodes(t *testing.T) *Shortcodes {
+    t.Helper()
+    dir := t.TempDir()
+    files := map[string]string{
+        "nota.html":  `<span class="nota">{{ .Get 0 }}</span>`,
+        "caja.html":  `<div class="caja">{{ .Inner }}</div>`,
+        "video.html": `<div class="video">{{ .Get "id" }}</div>`,
+        "url.html":   `<img src="{{ relURL "assets/x.png" }}" data-abs="{{ absURL "x/" }}" data-anio="{{ now.Year }}">`,
+    }
+    for name, content := range files {
+        if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    b := &Builder{
+        cfg:       Config{BaseURL: "/Yamblg/", UserUrl: "https://usuario.github.io"},
+        buildTime: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC),
+    }
+    base := template.Must(template.New("base").Funcs(b.funcMap()).Parse(""))
+    sc, err := loadShortcodes(base, dir)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return sc
+}
+
+func TestShortcodesRender(t *testing.T) {
+    sc := testShortcodes(t)
+
+    tests := []struct {
+        name   string
+        format string
+        body   string
+        want   string
+    }{
+        {"simple", FormatMarkdown, "Hola {{< nota uno >}}", `<p>Hola <span class="nota">uno</span></p>`},
+        {"código inline", FormatMarkdown, "Usar `{{< nota uno >}}` así", "<p>Usar <code>{{&lt; nota uno &gt;}}</code> así</p>"},
+        {"código inline doble", FormatMarkdown, "Usar ``{{< nota ` >}}`` así", "<p>Usar <code>{{&lt; nota ` &gt;}}</code> así</p>"},
+        {"bloque de código", FormatMarkdown, "```\n{{< caja >}}x{{< /caja >}}\n```", "<pre><code>{{&lt; caja &gt;}}x{{&lt; /caja &gt;}}\n</code></pre>"},
+        {"bloque de código ~~~", FormatMarkdown, "~~~\n{{< nota >}}\n~~~\n\n{{< nota dos >}}", "<pre><code>{{&lt; nota &gt;}}\n</code></pre>\n<p><span class=\"nota\">dos</span></p>"},
+        {"pre en HTML", FormatHTML, "<pre>{{< nota uno >}}</pre>", "<pre>{{< nota uno >}}</pre>"},
+        {"code en HTML", FormatHTML, `<p><code>{{< nota uno >}}</code> {{< nota dos >}}</p>`, `<p><code>{{< nota uno >}}</code> <span class="nota">dos</span></p>`},
+        {"escapado", FormatMarkdown, "Se escribe {{</* nota uno */>}}", "<p>Se escribe {{&lt; nota uno &gt;}}</p>"},
+        {"escapado en HTML", FormatHTML, "{{</* caja */>}}", "{{< caja >}}"},
+        {"anidados del mismo nombre", FormatHTML, "{{< caja >}}a{{< caja >}}b{{< /caja >}}c{{< /caja >}}", `<div class="caja">a<div class="caja">b</div>c</div>`},
+        {"bloque solo en su línea", FormatMarkdown, "Antes\n\n{{< video id=\"x\" >}}\n\nDespués", "<p>Antes</p>\n<div class=\"video\">x</div>\n<p>Después</p>"},
+        {"bloque en medio de un párrafo", FormatMarkdown, "Antes {{< video id=\"x\" >}} después", "<p>Antes</p>\n<div class=\"video\">x</div>\n<p>después</p>"},
+        {"bloque al final de un párrafo", FormatMarkdown, "Antes {{< video id=\"x\" >}}", "<p>Antes</p>\n<div class=\"video\">x</div>"},
+        {"funciones de URL y fecha", FormatMarkdown, "{{< url >}}", `<p><img src="/Yamblg/assets/x.png" data-abs="https://usuario.github.io/Yamblg/x/" data-anio="2025"></p>`},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := sc.render(tt.body, tt.format, &Post{})
+            if err != nil {
+                t.Fatal(err)
+            }
+            if strings.TrimSpace(got) != tt.want {
+                t.Errorf("render(%q)\n obtuve: %q\nquería: %q", tt.body, strings.TrimSpace(got), tt.want)
+            }
+        })
+    }
+}
+
+func TestShortcodesErrors(t *testing.T) {
+    sc := testShortcodes(t)
+
+    tests := []struct {
+        name string
+        body string
+        err  string
+    }{
+        {"desconocido", "{{< galeria >}}", `shortcode desconocido "galeria"`},
+        {"cerrado sin abrir", "texto {{< /caja >}}", `shortcode "caja" cerrado sin abrir`},
+        {"parámetro mal escrito", `{{< nota "sin cerrar\" >}}`, "parámetro mal escrito"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            _, err := sc.render(tt.body, FormatMarkdown, &Post{})
+            if err == nil || !strings.Contains(err.Error(), tt.err) {
+                t.Fatalf("error = %v, quería %q", err, tt.err)
+            }
+        })
+    }
+}
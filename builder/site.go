@@ -0,0 +1,150 @@
+package builder
+
+import (
+    "strconv"
+    "time"
+)
+
+// Tipos de página (.Page.Kind)
+const (
+    KindHome       = "home"
+    KindPage       = "page"       // template suelto de pages/ (lista-de-posteos, etc.)
+    KindPost       = "post"
+    KindStandalone = "standalone" // content/pages/
+    KindTaxonomy   = "taxonomy"
+    KindTerms      = "terms"
+    KindSection    = "section"
+    KindArchive    = "archive"
+    KindSeries     = "series"
+    Kind404        = "404"
+)
+
+// Todo lo que se conoce del sitio. Es el mismo valor en todos los templates (.Site)
+type Site struct {
+    Config      Config
+    BaseURL     string
+    Title       string
+    Posts       []Post
+    Pages       []Post
+    Taxonomies  map[string]*Taxonomy
+    Sections    []*Section
+    Archive     []YearGroup
+    Series      []*Series
+    Data        map[string]any
+    BuildTime   time.Time
+    Environment string // "production" o "development"
+    IsDev       bool
+}
+
+// La página que se está renderizando (.Page)
+type Page struct {
+    Kind        string
+    Title       string
+    Description string
+    Link        string // Relativo a BaseURL
+    Permalink   string // URL absoluta
+    Post        *Post  // Post o página suelta, si corresponde
+}
+
+// Datos que recibe cada template. Además de .Site y .Page se mantienen
+// los campos de siempre (.BaseURL, .Posts, .Post, ...) para no romper templates.
+type TemplateData struct {
+    Site *Site
+    Page *Page
+
+    BaseURL      string
+    Title        string
+    ActiveLasted bool
+    ActivePinned bool
+    Posts        []Post
+    Latest       []Post
+    CantPost     string
+    Pages        []Post
+    Taxonomies   map[string]*Taxonomy
+    Sections     []*Section
+
+    Post      *Post
+    Paginator *Paginator
+    Taxonomy  *Taxonomy
+    Term      *Term
+    Section   *Section
+    Series    *Series
+    Archive   []YearGroup
+    Years     []YearGroup
+    Year      *YearGroup
+    Month     *MonthGroup
+}
+
+func NewSite(cfg Config, isDev bool, buildTime time.Time, posts []Post, pages []Post, data map[string]any) *Site {
+    env := "production"
+    if isDev {
+        env = "development"
+    }
+
+    return &Site{
+        Config:      cfg,
+        BaseURL:     cfg.BaseURL,
+        Title:       cfg.SiteTitle,
+        Posts:       posts,
+        Pages:       pages,
+        Data:        data,
+        BuildTime:   buildTime,
+        Environment: env,
+        IsDev:       isDev,
+    }
+}
+
+// URL absoluta de una ruta relativa a BaseURL
+func (s *Site) AbsURL(link string) string {
+    return s.Config.AbsURL(link)
+}
+
+func (s *Site) newPage(kind string, title string, link string) *Page {
+    return &Page{
+        Kind:      kind,
+        Title:     title,
+        Link:      link,
+        Permalink: s.AbsURL(link),
+    }
+}
+
+func (s *Site) postPage(kind string, p *Post) *Page {
+    page := s.newPage(kind, p.Title, p.Link)
+    page.Description = p.Description
+    page.Post = p
+    return page
+}
+
+// Datos base de un template: los campos del sitio ya completos
+func (b *Builder) data(page *Page) TemplateData {
+    s := b.site
+    limite := min(len(s.Posts), s.Config.UseSectionPost.LimitOfPost)
+
+    return TemplateData{
+        Site:         s,
+        Page:         page,
+        BaseURL:      s.BaseURL,
+        Title:        s.Title,
+        ActiveLasted: s.Config.UseSectionPost.Active,
+        ActivePinned: s.Config.UsePinned.Active,
+        Posts:        s.Posts,
+        Latest:       LatestPosts(s.Posts)[:limite],
+        CantPost:     strconv.Itoa(limite),
+        Pages:        s.Pages,
+        Taxonomies:   s.Taxonomies,
+        Sections:     s.Sections,
+        Archive:      s.Archive,
+    }
+}
+
+// Renderiza un template de pages/ y agrega el Live Reload en desarrollo
+func (b *Builder) render(contentTemplate string, data TemplateData) (RenderResult, error) {
+    result, err := b.BuildPage(contentTemplate, data)
+    if err != nil {
+        return result, err
+    }
+    if b.site.IsDev {
+        result.Content = injectLiveReload(result.Content)
+    }
+    return result, nil
+}
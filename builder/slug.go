@@ -0,0 +1,68 @@
+package builder
+
+import (
+    "regexp"
+    "strings"
+    "unicode"
+
+    "golang.org/x/text/unicode/norm"
+)
+
+// Letras que no se separan en letra + tilde con NFD
+var slugTransliterations = map[rune]string{
+    'ß': "ss",
+    'æ': "ae",
+    'œ': "oe",
+    'ø': "o",
+    'đ': "d",
+    'ð': "d",
+    'ł': "l",
+    'þ': "th",
+    'ı': "i",
+}
+
+// Convierte un título en slug para URLs.
+// Las letras acentuadas se transliteran (á -> a, ñ -> n, ü -> u) y las de otros
+// alfabetos (cirílico, griego, CJK...) se conservan tal cual.
+// "¿Por qué hacer un mini SSG?" -> "por-que-hacer-un-mini-ssg"
+func slugify(s string) string {
+    var b strings.Builder
+    guion := false
+    latina := false
+
+    for _, r := range norm.NFD.String(strings.ToLower(s)) {
+        switch {
+        case unicode.Is(unicode.M, r):
+            // Tilde, diéresis, etc. separadas por NFD: se quitan solo de letras latinas.
+            // En otros alfabetos son parte de la palabra (dakuten japonés, vocales
+            // del devanagari como en "हिन्दी"), sean o no de ancho propio.
+            if !latina && !guion && b.Len() > 0 {
+                b.WriteRune(r)
+            }
+        case slugTransliterations[r] != "":
+            b.WriteString(slugTransliterations[r])
+            guion, latina = false, true
+        case unicode.IsLetter(r) || unicode.IsDigit(r):
+            b.WriteRune(r)
+            guion, latina = false, unicode.Is(unicode.Latin, r) || r < unicode.MaxASCII
+        default:
+            latina = false
+            if !guion && b.Len() > 0 {
+                b.WriteByte('-')
+                guion = true
+            }
+        }
+    }
+
+    return norm.NFC.String(strings.Trim(b.String(), "-"))
+}
+
+var legacySlugReg = regexp.MustCompile("[^a-z0-9]+")
+
+// Slug de versiones anteriores (descarta todo lo que no sea ASCII).
+// Se usa solo para generar redirecciones desde las URLs viejas.
+func legacySlugify(s string) string {
+    s = strings.ToLower(s)
+    s = legacySlugReg.ReplaceAllString(s, "-")
+    return strings.Trim(s, "-")
+}
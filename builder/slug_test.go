@@ -0,0 +1,58 @@
+package builder
+
+import "testing"
+
+func TestSlugify(t *testing.T) {
+    tests := []struct {
+        in   string
+        want string
+    }{
+        {"¿Por qué hacer un mini SSG?", "por-que-hacer-un-mini-ssg"},
+        {"Año", "ano"},
+        {"Pingüino", "pinguino"},
+        {"Straße", "strasse"},
+        {"  Hola,   mundo!  ", "hola-mundo"},
+        {"Go 1.22", "go-1-22"},
+        {"Привет, мир", "привет-мир"},
+        {"Ёлка", "ёлка"},
+        {"你好 世界", "你好-世界"},
+        {"日本語のブログ", "日本語のブログ"},
+        {"한국어", "한국어"},
+        {"हिन्दी पाठ", "हिन्दी-पाठ"},
+        {"¿?", ""},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.in, func(t *testing.T) {
+            if got := slugify(tt.in); got != tt.want {
+                t.Errorf("slugify(%q) = %q, quería %q", tt.in, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestLegacySlugify(t *testing.T) {
+    // Pares slug viejo / slug nuevo: de los distintos salen las redirecciones
+    tests := []struct {
+        in     string
+        legacy string
+        nuevo  string
+    }{
+        {"¿Por qué hacer un mini SSG?", "por-qu-hacer-un-mini-ssg", "por-que-hacer-un-mini-ssg"},
+        {"Año", "a-o", "ano"},
+        {"Pingüino", "ping-ino", "pinguino"},
+        {"Hola mundo", "hola-mundo", "hola-mundo"},
+        {"Привет, мир", "", "привет-мир"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.in, func(t *testing.T) {
+            if got := legacySlugify(tt.in); got != tt.legacy {
+                t.Errorf("legacySlugify(%q) = %q, quería %q", tt.in, got, tt.legacy)
+            }
+            if got := slugify(tt.in); got != tt.nuevo {
+                t.Errorf("slugify(%q) = %q, quería %q", tt.in, got, tt.nuevo)
+            }
+        })
+    }
+}
@@ -0,0 +1,91 @@
+package builder
+
+import (
+    "fmt"
+    "sort"
+    "strings"
+)
+
+// Criterios de orden aceptados en sortPosts.by
+const (
+    SortByDate    = "date"
+    SortByTitle   = "title"
+    SortByWeight  = "weight"
+    SortByUpdated = "updated"
+)
+
+// Orden natural de cada criterio cuando no se indica sortPosts.order
+var defaultSortOrder = map[string]string{
+    SortByDate:    "desc",
+    SortByTitle:   "asc",
+    SortByWeight:  "asc",
+    SortByUpdated: "desc",
+}
+
+// Compara dos posts según el criterio. Devuelve <0, 0 o >0 (orden ascendente)
+func comparePosts(a, b Post, by string) int {
+    switch by {
+    case SortByTitle:
+        return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+    case SortByWeight:
+        return a.Weight - b.Weight
+    case SortByUpdated:
+        return a.LastMod().Compare(b.LastMod())
+    }
+    return a.Date.Compare(b.Date.Time)
+}
+
+// Ordena los posts in-place según config.yaml.
+// Los empates se resuelven por fecha (desc) y título para que el resultado no dependa del nombre de archivo.
+func SortPosts(posts []Post, cfg Config) error {
+    by := strings.ToLower(cfg.SortPosts.By)
+    if by == "" {
+        by = SortByDate
+    }
+
+    order, ok := defaultSortOrder[by]
+    if !ok {
+        return fmt.Errorf("sortPosts.by desconocido %q (usar date, title, weight o updated)", cfg.SortPosts.By)
+    }
+    if cfg.SortPosts.Order != "" {
+        order = strings.ToLower(cfg.SortPosts.Order)
+    }
+    if order != "asc" && order != "desc" {
+        return fmt.Errorf("sortPosts.order desconocido %q (usar asc o desc)", cfg.SortPosts.Order)
+    }
+
+    sort.SliceStable(posts, func(i, j int) bool {
+        a, b := posts[i], posts[j]
+
+        if cfg.SortPosts.PinnedFirst && a.Fijado != b.Fijado {
+            return a.Fijado
+        }
+
+        if c := comparePosts(a, b, by); c != 0 {
+            if order == "desc" {
+                return c > 0
+            }
+            return c < 0
+        }
+        if c := a.Date.Compare(b.Date.Time); c != 0 {
+            return c > 0
+        }
+        return a.Title < b.Title
+    })
+
+    return nil
+}
+
+// Copia de los posts ordenada por fecha (más nuevo primero), para "Latest" y el feed
+func LatestPosts(posts []Post) []Post {
+    latest := make([]Post, len(posts))
+    copy(latest, posts)
+
+    sort.SliceStable(latest, func(i, j int) bool {
+        if c := latest[i].Date.Compare(latest[j].Date.Time); c != 0 {
+            return c > 0
+        }
+        return latest[i].Title < latest[j].Title
+    })
+    return latest
+}
@@ -0,0 +1,27 @@
+package builder
+
+import (
+    "strings"
+)
+
+// Marcador para cortar el resumen a mano dentro del body
+const moreMarker = "<!--more-->"
+
+// Cantidad de palabras del resumen automático si config.yaml no indica summaryLength
+const defaultSummaryLength = 70
+
+// Resumen en texto plano del body: lo que está antes de <!--more--> o las primeras n palabras
+func summarize(body string, n int) string {
+    if i := strings.Index(body, moreMarker); i >= 0 {
+        return plainify(body[:i])
+    }
+
+    if n <= 0 {
+        n = defaultSummaryLength
+    }
+    words := strings.Fields(plainify(body))
+    if len(words) <= n {
+        return strings.Join(words, " ")
+    }
+    return strings.TrimRight(strings.Join(words[:n], " "), " ,.;:") + "…"
+}
@@ -0,0 +1,100 @@
+package builder
+
+import (
+    "sort"
+    "strings"
+)
+
+// Taxonomías soportadas: nombre de la carpeta en public/ -> campo del post
+var taxonomyNames = []string{"tags", "categories"}
+
+type Term struct {
+    Name  string
+    Slug  string
+    Link  string
+    Posts []Post
+}
+
+type Taxonomy struct {
+    Name  string
+    Link  string
+    Terms []*Term
+}
+
+// Referencia liviana a un término, para usar desde un post
+type TermRef struct {
+    Name string
+    Link string
+}
+
+func (p Post) termsOf(taxonomy string) []string {
+    switch taxonomy {
+    case "tags":
+        return p.Tags
+    case "categories":
+        return p.Categories
+    }
+    return nil
+}
+
+func termRefs(taxonomy string, names []string) []TermRef {
+    var refs []TermRef
+    for _, name := range names {
+        slug := slugify(name)
+        if slug == "" {
+            continue
+        }
+        refs = append(refs, TermRef{Name: name, Link: taxonomy + "/" + slug + "/"})
+    }
+    return refs
+}
+
+// Para el template: {{ range .Post.TagRefs }}
+func (p Post) TagRefs() []TermRef {
+    return termRefs("tags", p.Tags)
+}
+
+func (p Post) CategoryRefs() []TermRef {
+    return termRefs("categories", p.Categories)
+}
+
+// Agrupa los posts por cada término de cada taxonomía.
+// Los términos que producen el mismo slug ("Go" y "go") se unifican.
+func BuildTaxonomies(posts []Post) map[string]*Taxonomy {
+    taxonomies := make(map[string]*Taxonomy)
+
+    for _, name := range taxonomyNames {
+        tax := &Taxonomy{Name: name, Link: name + "/"}
+        bySlug := make(map[string]*Term)
+
+        for _, p := range posts {
+            seen := make(map[string]bool)
+            for _, termName := range p.termsOf(name) {
+                slug := slugify(termName)
+                if slug == "" || seen[slug] {
+                    continue
+                }
+                seen[slug] = true
+
+                term, ok := bySlug[slug]
+                if !ok {
+                    term = &Term{
+                        Name: strings.TrimSpace(termName),
+                        Slug: slug,
+                        Link: name + "/" + slug + "/",
+                    }
+                    bySlug[slug] = term
+                    tax.Terms = append(tax.Terms, term)
+                }
+                term.Posts = append(term.Posts, p)
+            }
+        }
+
+        sort.Slice(tax.Terms, func(i, j int) bool {
+            return tax.Terms[i].Slug < tax.Terms[j].Slug
+        })
+        taxonomies[name] = tax
+    }
+
+    return taxonomies
+}
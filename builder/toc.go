@@ -0,0 +1,96 @@
+package builder
+
+import (
+    "fmt"
+    "regexp"
+)
+
+// Entrada del índice (.Post.TableOfContents)
+type TocEntry struct {
+    ID       string
+    Title    string
+    Level    int
+    Children []*TocEntry
+}
+
+var (
+    headingReg = regexp.MustCompile(`(?is)<h([2-4])(\s[^>]*)?>(.*?)</h[2-4]\s*>`)
+    idAttrReg  = regexp.MustCompile(`(?i)\sid\s*=\s*["']([^"']*)["']`)
+)
+
+// Agrega un id estable a cada h2-h4 del body (si no lo tiene) y arma el índice anidado.
+// Los ids salen del texto del título; si se repiten se numeran (instalacion, instalacion-1, ...).
+func buildTableOfContents(body string) (string, []*TocEntry) {
+    usados := make(map[string]int)
+    var planos []*TocEntry
+
+    // Los ids escritos a mano se respetan y se reservan primero
+    for _, m := range headingReg.FindAllStringSubmatch(body, -1) {
+        if id := idAttrReg.FindStringSubmatch(m[2]); id != nil {
+            usados[id[1]]++
+        }
+    }
+
+    body = headingReg.ReplaceAllStringFunc(body, func(h string) string {
+        m := headingReg.FindStringSubmatch(h)
+        level := int(m[1][0] - '0')
+        attrs, inner := m[2], m[3]
+        title := plainify(inner)
+
+        id := ""
+        if existente := idAttrReg.FindStringSubmatch(attrs); existente != nil {
+            id = existente[1]
+        } else {
+            base := slugify(title)
+            if base == "" {
+                base = "seccion"
+            }
+            id = base
+            for usados[id] > 0 {
+                id = fmt.Sprintf("%s-%d", base, usados[base])
+                usados[base]++
+            }
+            usados[id]++
+            attrs = fmt.Sprintf(` id="%s"`, id) + attrs
+        }
+
+        planos = append(planos, &TocEntry{ID: id, Title: title, Level: level})
+        return fmt.Sprintf("<h%d%s>%s</h%d>", level, attrs, inner, level)
+    })
+
+    return body, nestToc(planos)
+}
+
+// Anida las entradas según el nivel (h3 dentro del h2 anterior, etc.)
+func nestToc(planos []*TocEntry) []*TocEntry {
+    var raiz []*TocEntry
+    var pila []*TocEntry
+
+    for _, e := range planos {
+        for len(pila) > 0 && pila[len(pila)-1].Level >= e.Level {
+            pila = pila[:len(pila)-1]
+        }
+        if len(pila) == 0 {
+            raiz = append(raiz, e)
+        } else {
+            padre := pila[len(pila)-1]
+            padre.Children = append(padre.Children, e)
+        }
+        pila = append(pila, e)
+    }
+    return raiz
+}
+
+// Cantidad total de entradas del índice, para decidir si mostrarlo
+func (p Post) TocSize() int {
+    var contar func([]*TocEntry) int
+    contar = func(entries []*TocEntry) int {
+        n := 0
+        for _, e := range entries {
+            n += 1 + contar(e.Children)
+        }
+        return n
+    }
+    return contar(p.TableOfContents)
+}
+
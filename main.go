@@ -29,14 +29,18 @@ var (
 func main() {
 	var rootCmd = &cobra.Command{Use: "yamblg"}
 
+	var buildOpts, serveOpts builder.BuildOptions
+
 	var buildCmd = &cobra.Command{
 		Use:   "build",
 		Short: "Producción",
 		Run: func(cmd *cobra.Command, args []string) {
 			fs := afero.NewOsFs()
-			builder.RunBuild(fs, false)
+			builder.RunBuild(fs, false, buildOpts)
 		},
 	}
+	buildCmd.Flags().BoolVar(&buildOpts.Drafts, "drafts", false, "Incluye los posts con draft: true")
+	buildCmd.Flags().BoolVar(&buildOpts.Future, "future", false, "Incluye los posts con fecha futura")
 
 	var serveCmd = &cobra.Command{
 		Use:   "serve",
@@ -45,7 +49,7 @@ func main() {
 			memFs := afero.NewMemMapFs()
 			sourceFs := afero.NewOsFs()
 
-			builder.RunBuild(memFs, true)
+			builder.RunBuild(memFs, true, serveOpts)
 
 			// Canal de comunicación para el reload
 			go func() {
@@ -57,10 +61,13 @@ func main() {
 				}
 			}()
 
-			go iniciarWatcher(sourceFs, memFs)
+			go iniciarWatcher(sourceFs, memFs, serveOpts)
 			iniciarServidor(memFs)
 		},
 	}
+	// En desarrollo se muestran por defecto (--drafts=false para ocultarlos)
+	serveCmd.Flags().BoolVar(&serveOpts.Drafts, "drafts", true, "Incluye los posts con draft: true")
+	serveCmd.Flags().BoolVar(&serveOpts.Future, "future", true, "Incluye los posts con fecha futura")
 
 	var initCmd = &cobra.Command{
 	Use:   "init [directorio]",
@@ -117,23 +124,81 @@ func main() {
 	},
 }
 
-	rootCmd.AddCommand(buildCmd, serveCmd,initCmd)
+	var fixDates bool
+	var datesCmd = &cobra.Command{
+		Use:   "dates",
+		Short: "Lista los posts sin fecha (con --fix les agrega la fecha)",
+		Run: func(cmd *cobra.Command, args []string) {
+			sinFecha, err := builder.ConfigYaml(fixDates)
+			if err != nil {
+				fmt.Printf("❌ Error: %v\n", err)
+				return
+			}
+
+			if len(sinFecha) == 0 {
+				fmt.Println("✅ Todos los posts tienen fecha.")
+				return
+			}
+
+			for _, path := range sinFecha {
+				if fixDates {
+					fmt.Printf("  + Fecha agregada en %s\n", path)
+				} else {
+					fmt.Printf("  - Sin fecha: %s\n", path)
+				}
+			}
+			if !fixDates {
+				fmt.Println("Usá --fix para completarlas con la fecha de modificación del archivo.")
+			}
+		},
+	}
+	datesCmd.Flags().BoolVar(&fixDates, "fix", false, "Escribe la fecha en los archivos")
+
+	var genStyleCmd = &cobra.Command{
+		Use:   "gen-style <tema>",
+		Short: "Genera style/syntax.css con los colores del tema para el código",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			destino, err := builder.GenerateStyle(args[0])
+			if err != nil {
+				fmt.Printf("❌ Error: %v\n", err)
+				return
+			}
+			fmt.Printf("✅ Estilos de código generados en %s\n", destino)
+		},
+	}
+
+	rootCmd.AddCommand(buildCmd, serveCmd,initCmd, datesCmd, genStyleCmd)
 	rootCmd.Execute()
 }
 
-func iniciarWatcher(sourceFs afero.Fs, memFs afero.Fs) {
+func iniciarWatcher(sourceFs afero.Fs, memFs afero.Fs, opts builder.BuildOptions) {
 	watcher, _ := fsnotify.NewWatcher()
 	defer watcher.Close()
 
-	dirs := []string{"assets","components","content", "pages", "layout", "style"}
+	dirs := []string{"assets","components","content", "data", "pages", "layout", "shortcodes", "style"}
 	for _, d := range dirs { _ = watcher.Add(d) }
 
+	// fsnotify no es recursivo: agregamos también las subcarpetas de content/
+	_ = filepath.WalkDir("content", func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() && path != "content" {
+			_ = watcher.Add(path)
+		}
+		return nil
+	})
+
 	for {
 		select {
 		case event := <-watcher.Events:
 			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove) != 0 {
+				// Carpeta nueva dentro de content/: empezamos a vigilarla
+				if event.Op&fsnotify.Create != 0 {
+					if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+						_ = watcher.Add(event.Name)
+					}
+				}
 				log.Printf("♻️  Cambio en %s. Actualizando...", event.Name)
-				builder.RunBuild(memFs, true)
+				builder.RunBuild(memFs, true, opts)
 				notificar <- true
 			}
 		}
@@ -168,10 +233,33 @@ func iniciarServidor(memFs afero.Fs) {
             w.Header().Set("Content-Type", "application/javascript; charset=utf-8")
         }
 
-        // 3. Servir el archivo
+        // 3. Si no existe, devolvemos public/404.html con estado 404
+        if !existeEnPublic(publicDir, path) {
+            if pagina, err := afero.ReadFile(publicDir, "404.html"); err == nil {
+                w.Header().Set("Content-Type", "text/html; charset=utf-8")
+                w.WriteHeader(http.StatusNotFound)
+                w.Write(pagina)
+                return
+            }
+        }
+
+        // 4. Servir el archivo
         fileserver.ServeHTTP(w, r)
     })
 
     fmt.Println("🌍 Yamblg Dev Server: http://localhost:8080")
     log.Fatal(http.ListenAndServe(":8080", nil))
-}
\ No newline at end of file
+}
+
+// Indica si la ruta pedida existe (archivo o carpeta con index.html)
+func existeEnPublic(publicDir afero.Fs, path string) bool {
+    info, err := publicDir.Stat(path)
+    if err != nil {
+        return false
+    }
+    if info.IsDir() {
+        ok, _ := afero.Exists(publicDir, filepath.Join(path, "index.html"))
+        return ok
+    }
+    return true
+}